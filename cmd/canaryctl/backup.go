@@ -0,0 +1,104 @@
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func backupMain(args []string) {
+	fs := flag.NewFlagSet("backup", flag.ExitOnError)
+	var (
+		addr  = fs.String("addr", "http://localhost:8080", "address of the canaryd to backup")
+		token = fs.String("token", os.Getenv("CANARY_ADMIN_TOKEN"), "bearer token with the admin role on the canaryd")
+		out   = fs.String("out", "canary-backup.db", "file in which to save the backup")
+		ca    = fs.String("ca", "", "path to PEM CAs to verify the canaryd with, instead of those of the system")
+		cert  = fs.String("cert", "", "path to a PEM client certificate, for a canaryd requiring one")
+		key   = fs.String("key", "", "path to the PEM key of cert")
+	)
+	fs.Parse(args)
+
+	client, err := newClient(*ca, *cert, *key)
+	if err != nil {
+		log.Fatal(err)
+	}
+	n, err := fetchBackup(client, strings.TrimSuffix(*addr, "/")+"/admin/backup", *token, *out)
+	if err != nil {
+		log.Fatalf("backing up %s: %v", *addr, err)
+	}
+	log.Printf("saved %d bytes to %s", n, *out)
+}
+
+// newClient creates a client verifying the server with the given CAs, if any,
+// and presenting the given certificate, if any.
+func newClient(caFile, certFile, keyFile string) (*http.Client, error) {
+	if (certFile == "") != (keyFile == "") {
+		return nil, fmt.Errorf("cert and key go together")
+	}
+	cfg := &tls.Config{MinVersion: tls.VersionTLS12}
+	if caFile != "" {
+		pem, err := ioutil.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("can't read CAs: %v", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificate found in %s", caFile)
+		}
+		cfg.RootCAs = pool
+	}
+	if certFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("can't load client certificate: %v", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = cfg
+	return &http.Client{Transport: transport}, nil
+}
+
+// fetchBackup downloads the backup next to the destination file, and only
+// moves it in place once it was entirely received.
+func fetchBackup(client *http.Client, url, token, dst string) (int64, error) {
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 512))
+		return 0, fmt.Errorf("unexpected status %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(dst), filepath.Base(dst)+".partial")
+	if err != nil {
+		return 0, err
+	}
+	defer os.Remove(tmp.Name())
+
+	n, err := io.Copy(tmp, resp.Body)
+	if err != nil {
+		tmp.Close()
+		return n, err
+	}
+	if err := tmp.Close(); err != nil {
+		return n, err
+	}
+	return n, os.Rename(tmp.Name(), dst)
+}
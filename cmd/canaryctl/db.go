@@ -0,0 +1,195 @@
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	dbpkg "github.com/iheanyi/simple-canary/internal/db"
+)
+
+const dbUsage = `usage: canaryctl db <command> [flags]
+
+commands:
+  export   write the tests recorded in a Bolt file as NDJSON or CSV
+  import   load tests from NDJSON into a store, skipping known test IDs`
+
+func dbMain(args []string) {
+	if len(args) == 0 {
+		log.Fatal(dbUsage)
+	}
+	switch args[0] {
+	case "export":
+		dbExport(args[1:])
+	case "import":
+		dbImport(args[1:])
+	default:
+		log.Fatalf("unknown db command %q\n%s", args[0], dbUsage)
+	}
+}
+
+func dbExport(args []string) {
+	fs := flag.NewFlagSet("db export", flag.ExitOnError)
+	var (
+		dbPath = fs.String("db.file", "canary.db", "Bolt file from which to export, must not be in use by canaryd")
+		format = fs.String("format", "ndjson", "format in which to write the tests, one of: ndjson, csv")
+		name   = fs.String("name", "", "only export the tests with this name")
+		since  = fs.String("since", "", "only export the tests started at or after this RFC3339 time")
+		until  = fs.String("until", "", "only export the tests started before this RFC3339 time")
+		out    = fs.String("out", "", "file to write to, defaults to stdout")
+	)
+	fs.Parse(args)
+
+	from, err := parseTimeFlag("since", *since)
+	if err != nil {
+		log.Fatal(err)
+	}
+	to, err := parseTimeFlag("until", *until)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	db, err := dbpkg.NewReadOnlyBoltStore(*dbPath)
+	if err != nil {
+		log.Fatalf("opening %q: %v", *dbPath, err)
+	}
+	defer db.Close()
+
+	tests, err := db.ListTests()
+	if err != nil {
+		log.Fatalf("listing tests: %v", err)
+	}
+	sort.Sort(byStartAt(tests))
+
+	var w io.Writer = os.Stdout
+	if *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer f.Close()
+		w = f
+	}
+	bw := bufio.NewWriter(w)
+	defer bw.Flush()
+
+	var write func(dbpkg.TestInstance) error
+	switch *format {
+	case "ndjson":
+		enc := json.NewEncoder(bw)
+		write = func(test dbpkg.TestInstance) error {
+			return enc.Encode(test)
+		}
+	case "csv":
+		cw := csv.NewWriter(bw)
+		defer cw.Flush()
+		if err := cw.Write([]string{"id", "name", "start_at", "end_at", "pass", "fail_cause", "fail_kind"}); err != nil {
+			log.Fatal(err)
+		}
+		write = func(test dbpkg.TestInstance) error {
+			return cw.Write([]string{
+				test.TestID,
+				test.TestName,
+				test.StartAt.Format(time.RFC3339Nano),
+				test.EndAt.Format(time.RFC3339Nano),
+				strconv.FormatBool(test.Pass),
+				test.FailCause,
+				string(test.FailKind),
+			})
+		}
+	default:
+		log.Fatalf("unknown format %q", *format)
+	}
+
+	for _, test := range tests {
+		if *name != "" && test.TestName != *name {
+			continue
+		}
+		if !from.IsZero() && test.StartAt.Before(from) {
+			continue
+		}
+		if !to.IsZero() && !test.StartAt.Before(to) {
+			continue
+		}
+		if err := write(test); err != nil {
+			log.Fatalf("writing test %q: %v", test.TestID, err)
+		}
+	}
+}
+
+func dbImport(args []string) {
+	fs := flag.NewFlagSet("db import", flag.ExitOnError)
+	var (
+		dbDriver = fs.String("db.driver", "bolt", "database to import into, one of: bolt, sqlite")
+		dbPath   = fs.String("db.file", "canary.db", "file for the canary database, must not be in use by canaryd")
+		in       = fs.String("in", "", "NDJSON file to read from, defaults to stdin")
+	)
+	fs.Parse(args)
+
+	var r io.Reader = os.Stdin
+	if *in != "" {
+		f, err := os.Open(*in)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer f.Close()
+		r = f
+	}
+
+	db, err := dbpkg.Open(*dbDriver, *dbPath)
+	if err != nil {
+		log.Fatalf("opening %q: %v", *dbPath, err)
+	}
+	defer db.Close()
+
+	var imported, skipped int
+	dec := json.NewDecoder(bufio.NewReader(r))
+	for {
+		var test dbpkg.TestInstance
+		err := dec.Decode(&test)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			log.Fatalf("reading test %d: %v", imported+skipped+1, err)
+		}
+		if test.TestID == "" {
+			log.Fatalf("test %d has no ID", imported+skipped+1)
+		}
+
+		saved, err := db.ImportTest(&test)
+		if err != nil {
+			log.Fatalf("importing test %q: %v", test.TestID, err)
+		}
+		if saved {
+			imported++
+		} else {
+			skipped++
+		}
+	}
+	log.Printf("imported %d tests, skipped %d already known", imported, skipped)
+}
+
+func parseTimeFlag(name, value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return t, fmt.Errorf("invalid -%s: %v", name, err)
+	}
+	return t, nil
+}
+
+type byStartAt []dbpkg.TestInstance
+
+func (by byStartAt) Len() int           { return len(by) }
+func (by byStartAt) Less(i, j int) bool { return by[i].StartAt.Before(by[j].StartAt) }
+func (by byStartAt) Swap(i, j int)      { by[i], by[j] = by[j], by[i] }
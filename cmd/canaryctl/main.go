@@ -20,6 +20,20 @@ func main() {
 	log.SetFlags(0)
 	log.SetPrefix("")
 
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "db":
+			dbMain(os.Args[2:])
+			return
+		case "backup":
+			backupMain(os.Args[2:])
+			return
+		case "gen-rules":
+			genRulesMain(os.Args[2:])
+			return
+		}
+	}
+
 	var (
 		workDir     = flag.String("dir", "", "dir from which to start")
 		cfgPath     = flag.String("cfg", "config.js", "path to a JS config file")
@@ -72,8 +86,11 @@ func main() {
 			defer cancel()
 			err := runner.Run(ctx, vm, jsctx, test, "debug")
 			switch e := err.(type) {
-			case *otto.Error:
-				log.Print(e.String())
+			case nil:
+				log.Print("passed")
+				return
+			case *runner.Failure:
+				log.Printf("failed (%s): %v", e.Kind, e.Err)
 			default:
 				log.Print(err)
 			}
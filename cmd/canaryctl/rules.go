@@ -0,0 +1,225 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/iheanyi/simple-canary/internal/js"
+	"github.com/iheanyi/simple-canary/internal/js/canary"
+	"github.com/iheanyi/simple-canary/internal/slo"
+	"github.com/robertkrimen/otto"
+)
+
+// The burn rates over which the SLO alerts fire, per the multiwindow alerts of
+// the SRE workbook: the fast burn spends 2% of the budget of 30 days in an
+// hour, confirmed by 5% in 6 hours, and the slow one 10% in a day.
+const (
+	fastBurnRate = 14.4
+	confirmRate  = 6
+	slowBurnRate = 3
+)
+
+func genRulesMain(args []string) {
+	fs := flag.NewFlagSet("gen-rules", flag.ExitOnError)
+	var (
+		workDir  = fs.String("dir", "", "dir from which to load the config")
+		cfgPath  = fs.String("cfg", "config.js", "path to a JS config file")
+		out      = fs.String("out", "", "file to write the rules to, defaults to stdout")
+		selector = fs.String("selector", "", `extra label matchers selecting the metrics of this canary, like job="canary"`)
+		ratio    = fs.Float64("failure-ratio", 0.5, "share of failed runs above which a test alerts")
+	)
+	fs.Parse(args)
+
+	if *workDir != "" {
+		if err := os.Chdir(*workDir); err != nil {
+			log.Fatal(err)
+		}
+	}
+	cfg, err := os.Open(*cfgPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer cfg.Close()
+	canaryConfig, testCfgs, err := canary.Load(otto.New(), cfg)
+	if err != nil {
+		log.Fatalf("loading configuration in vm: %v", err)
+	}
+
+	w := io.Writer(os.Stdout)
+	if *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer f.Close()
+		w = f
+	}
+	bw := bufio.NewWriter(w)
+	writeRules(bw, *cfgPath, canaryConfig, testCfgs, *selector, *ratio)
+	if err := bw.Flush(); err != nil {
+		log.Fatal(err)
+	}
+	if *out != "" {
+		log.Printf("wrote the rules of %d tests to %s", len(testCfgs), *out)
+	}
+}
+
+// A rule is a Prometheus alerting rule.
+type rule struct {
+	alert       string
+	expr        string
+	forDuration time.Duration
+	labels      map[string]string
+	annotations map[string]string
+}
+
+// writeRules writes a Prometheus rules file with a group of alerts per test.
+func writeRules(w io.Writer, cfgPath string, cfg *canary.Config, tests []*js.TestConfig, selector string, ratio float64) {
+	fmt.Fprintf(w, "# Generated by canaryctl gen-rules from %s, do not edit.\n", cfgPath)
+	fmt.Fprintf(w, "groups:\n")
+	for _, test := range tests {
+		fmt.Fprintf(w, "- name: %s\n", yamlQuote("canary "+cfg.Name+": "+test.Name))
+		fmt.Fprintf(w, "  rules:\n")
+		for _, r := range testRules(cfg, test, selector, ratio) {
+			fmt.Fprintf(w, "  - alert: %s\n", r.alert)
+			fmt.Fprintf(w, "    expr: %s\n", yamlQuote(r.expr))
+			if r.forDuration > 0 {
+				fmt.Fprintf(w, "    for: %s\n", promDuration(r.forDuration))
+			}
+			writeMap(w, "labels", r.labels)
+			writeMap(w, "annotations", r.annotations)
+		}
+	}
+}
+
+func writeMap(w io.Writer, name string, m map[string]string) {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	fmt.Fprintf(w, "    %s:\n", name)
+	for _, k := range keys {
+		fmt.Fprintf(w, "      %s: %s\n", k, yamlQuote(m[k]))
+	}
+}
+
+// testRules returns the alerts on the failures of the test, on it not running
+// anymore, and on the burn rates of its SLO if it has one.
+func testRules(cfg *canary.Config, test *js.TestConfig, selector string, ratio float64) []rule {
+	matchers := "test_name=" + strconv.Quote(test.Name)
+	if selector != "" {
+		matchers += "," + selector
+	}
+	labels := func(severity string) map[string]string {
+		l := map[string]string{"canary": cfg.Name, "test_name": test.Name}
+		for k, v := range test.Labels {
+			l[k] = v
+		}
+		// The severity of the test, if it has one, trumps that of the
+		// alert.
+		if _, ok := l["severity"]; !ok {
+			l["severity"] = severity
+		}
+		return l
+	}
+
+	// The failure ratio is taken over enough runs for a single failure not
+	// to be an alert on its own, unless the ratio is that low.
+	window := 5 * test.Frequency
+	if window < 5*time.Minute {
+		window = 5 * time.Minute
+	}
+	// A test is stale once it missed a few runs.
+	stale := 3*test.Frequency + test.Timeout
+
+	rules := []rule{
+		{
+			alert: "CanaryTestFailing",
+			expr: fmt.Sprintf(`sum(rate(test_finished_count{%s,result="fail"}[%s])) / sum(rate(test_finished_count{%s}[%s])) > %s`,
+				matchers, promDuration(window), matchers, promDuration(window), strconv.FormatFloat(ratio, 'g', -1, 64)),
+			labels: labels("page"),
+			annotations: map[string]string{
+				"summary":     fmt.Sprintf("Test %s of canary %s is failing", test.Name, cfg.Name),
+				"description": fmt.Sprintf("More than %v%% of the runs failed over the last %s.", ratio*100, promDuration(window)),
+			},
+		},
+		{
+			alert:  "CanaryTestStale",
+			expr:   fmt.Sprintf(`time() - (test_last_run_timestamp_seconds{%s} > 0) > %d`, matchers, int64(stale.Seconds())),
+			labels: labels("warning"),
+			annotations: map[string]string{
+				"summary":     fmt.Sprintf("Test %s of canary %s isn't running", test.Name, cfg.Name),
+				"description": fmt.Sprintf("The test, which runs every %s, last ran more than %s ago.", promDuration(test.Frequency), promDuration(stale)),
+			},
+		},
+	}
+	if test.SLO == nil {
+		return rules
+	}
+
+	burn := func(w time.Duration, rate float64) string {
+		return fmt.Sprintf(`test_slo_burn_rate{%s,window=%q} > %v`, matchers, slo.FormatWindow(w), rate)
+	}
+	target := strconv.FormatFloat(test.SLO.Target, 'g', -1, 64)
+	return append(rules,
+		rule{
+			alert:       "CanarySLOFastBurn",
+			expr:        burn(time.Hour, fastBurnRate) + " and ignoring(window) " + burn(6*time.Hour, confirmRate),
+			forDuration: 2 * time.Minute,
+			labels:      labels("page"),
+			annotations: map[string]string{
+				"summary":     fmt.Sprintf("Test %s of canary %s is burning through its error budget", test.Name, cfg.Name),
+				"description": fmt.Sprintf("At this rate, the budget of its %s%% SLO will be spent within days.", target),
+			},
+		},
+		rule{
+			alert:       "CanarySLOSlowBurn",
+			expr:        burn(24*time.Hour, slowBurnRate) + " and ignoring(window) " + burn(6*time.Hour, slowBurnRate),
+			forDuration: 15 * time.Minute,
+			labels:      labels("ticket"),
+			annotations: map[string]string{
+				"summary":     fmt.Sprintf("Test %s of canary %s is steadily spending its error budget", test.Name, cfg.Name),
+				"description": fmt.Sprintf("At this rate, the budget of its %s%% SLO will be spent before the end of its window.", target),
+			},
+		},
+	)
+}
+
+// promDuration formats the duration the way Prometheus reads them, like 1h30m.
+func promDuration(d time.Duration) string {
+	d = d.Round(time.Second)
+	if d == 0 {
+		return "0s"
+	}
+	var b strings.Builder
+	for _, unit := range []struct {
+		d      time.Duration
+		suffix string
+	}{
+		{24 * time.Hour, "d"},
+		{time.Hour, "h"},
+		{time.Minute, "m"},
+		{time.Second, "s"},
+	} {
+		if n := d / unit.d; n > 0 {
+			fmt.Fprintf(&b, "%d%s", n, unit.suffix)
+			d -= n * unit.d
+		}
+	}
+	return b.String()
+}
+
+// yamlQuote quotes the string for YAML, single quotes being the only character
+// to escape in single-quoted scalars.
+func yamlQuote(s string) string {
+	return "'" + strings.Replace(s, "'", "''", -1) + "'"
+}
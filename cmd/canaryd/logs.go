@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+
+	dbpkg "github.com/iheanyi/simple-canary/internal/db"
+	log "github.com/sirupsen/logrus"
+)
+
+// runLogs is a logrus hook that collects what's logged about each run, as
+// recognized by its "test.id" field, so it can be saved along with the run.
+type runLogs struct {
+	mu   sync.Mutex
+	logs map[string][]dbpkg.LogEntry
+}
+
+func newRunLogs() *runLogs {
+	return &runLogs{logs: make(map[string][]dbpkg.LogEntry)}
+}
+
+// begin starts collecting the logs of the run.
+func (rl *runLogs) begin(id string) {
+	rl.mu.Lock()
+	rl.logs[id] = []dbpkg.LogEntry{}
+	rl.mu.Unlock()
+}
+
+// end stops collecting the logs of the run, returning those collected.
+func (rl *runLogs) end(id string) []dbpkg.LogEntry {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	logs := rl.logs[id]
+	delete(rl.logs, id)
+	return logs
+}
+
+func (rl *runLogs) Levels() []log.Level {
+	return log.AllLevels
+}
+
+func (rl *runLogs) Fire(entry *log.Entry) error {
+	id, ok := entry.Data["test.id"].(string)
+	if !ok {
+		return nil
+	}
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	logs, ok := rl.logs[id]
+	if !ok {
+		return nil
+	}
+
+	fields := make(map[string]string, len(entry.Data))
+	for k, v := range entry.Data {
+		if k != "test.id" && k != "test.name" {
+			fields[k] = fmt.Sprint(v)
+		}
+	}
+	rl.logs[id] = append(logs, dbpkg.LogEntry{
+		Time:    entry.Time,
+		Level:   entry.Level.String(),
+		Message: entry.Message,
+		Fields:  fields,
+	})
+	return nil
+}
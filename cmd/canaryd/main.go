@@ -2,8 +2,8 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
 	"flag"
-	"fmt"
 	"net"
 	"net/http"
 	"os"
@@ -11,12 +11,19 @@ import (
 	"time"
 
 	"github.com/gorilla/mux"
+	"github.com/iheanyi/simple-canary/internal/alert"
 	"github.com/iheanyi/simple-canary/internal/app"
+	"github.com/iheanyi/simple-canary/internal/auth"
+	"github.com/iheanyi/simple-canary/internal/buildinfo"
 	dbpkg "github.com/iheanyi/simple-canary/internal/db"
+	"github.com/iheanyi/simple-canary/internal/events"
 	"github.com/iheanyi/simple-canary/internal/js"
 	"github.com/iheanyi/simple-canary/internal/js/canary"
+	jscontext "github.com/iheanyi/simple-canary/internal/js/context"
 	"github.com/iheanyi/simple-canary/internal/js/runner"
 	"github.com/iheanyi/simple-canary/internal/metrics"
+	"github.com/iheanyi/simple-canary/internal/scheduler"
+	"github.com/iheanyi/simple-canary/internal/slo"
 	"github.com/pborman/uuid"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/robertkrimen/otto"
@@ -31,12 +38,25 @@ func main() {
 	var (
 		cfgPath    = flag.String("cfg", "config.js", "path to a JS config file")
 		workDir    = flag.String("work.dir", ".", "directory from which to run, should match expectations about relative paths in cfg.file")
+		dbDriver   = flag.String("db.driver", "bolt", "database in which to keep the canary history, one of: bolt, sqlite")
 		dbPath     = flag.String("db.file", "canary.db", "file for the canary database")
+		dbRunsTTL  = flag.Duration("db.retention", 0, "how long to keep the runs of tests, 0 to keep them forever")
+		dbStatsTTL = flag.Duration("db.rollup-retention", 0, "how long to keep the hourly and daily rollups of tests, 0 to keep them forever")
 		listenHost = flag.String("listen.host", "", "interface on which to listen")
 		listenPort = flag.String("listen.port", "8080", "port on which to listen")
+		tlsCert    = flag.String("tls.cert", "", "path to a PEM certificate, to serve over HTTPS")
+		tlsKey     = flag.String("tls.key", "", "path to the PEM key of tls.cert")
+		tlsCA      = flag.String("tls.client-ca", "", "path to PEM CAs, one of which must have signed the certificates of clients")
+		extURL     = flag.String("external.url", "", "URL at which the canary is reached, used to link to runs from alerts; defaults to one on the hostname and listen.port")
+		adminToken = flag.String("admin.token", os.Getenv("CANARY_ADMIN_TOKEN"), "bearer token granting the admin role, in addition to those of the auth config")
+		authPath   = flag.String("auth.file", "", "path to a JSON auth config, overriding the auth settings of cfg")
 	)
 	flag.Parse()
 
+	if *dbStatsTTL != 0 && (*dbRunsTTL == 0 || *dbStatsTTL < *dbRunsTTL) {
+		log.Fatal("rollups must be retained at least as long as runs")
+	}
+
 	if *workDir != "" {
 		if err := os.Chdir(*workDir); err != nil {
 			log.WithError(err).WithField("work.dir", *workDir).Fatal("can't change dir")
@@ -49,29 +69,48 @@ func main() {
 	)
 
 	met, hdl := metrics.Prometheus()
-	l := mustListen(*listenHost, *listenPort)
-	db := mustOpenBolt(*dbPath)
+	l := mustListen(*listenHost, *listenPort, *tlsCert, *tlsKey, *tlsCA)
+	db := mustOpenStore(*dbDriver, *dbPath)
 	defer db.Close()
+	go pruneForever(db, *dbRunsTTL, *dbStatsTTL)
+
+	canaryCfg, testCfgs := mustLoadConfigs(vm, *cfgPath)
+	build := buildinfo.Read()
+	met.GaugeVec("canary_build_info", "Build of the canary and hash of its config", "version", "config_hash").
+		WithLabelValues(build.Version, canaryCfg.Hash).Set(1)
+	authCfg := mustLoadAuth(canaryCfg, *authPath)
+	var extra []auth.Token
+	if *adminToken != "" {
+		extra = append(extra, auth.Token{Name: "admin", Token: *adminToken, Role: auth.Admin})
+	}
+	sched := mustSchedule(db)
+	bus := events.NewBus()
+	log.AddHook(events.LogHook(bus))
+	logs := newRunLogs()
+	log.AddHook(logs)
 
-	if err := launchHTTP(ctx, l, hdl, db); err != nil {
+	if err := launchHTTP(ctx, l, hdl, db, bus, sched, canaryCfg, testCfgs, authCfg, authCfg.Guard(extra...)); err != nil {
 		log.WithError(err).Fatal("can't launch http server")
 	}
 
-	canaryCfg, testCfgs := mustLoadConfigs(vm, *cfgPath)
+	if *extURL == "" {
+		*extURL = mustExternalURL(l, *tlsCert != "")
+	}
+	alerts := mustAlert(db, canaryCfg, testCfgs, *extURL)
 
-	launchTests(db, met, vm, canaryCfg, testCfgs)
+	launchTests(sched, bus, logs, alerts, db, met, vm, canaryCfg, testCfgs)
 
 	// Block forever because we want the tests to run forever.
 	select {}
 }
 
-func launchTests(db dbpkg.CanaryStore, met *metrics.Node, vm *otto.Otto, config *canary.Config, configs []*js.TestConfig) {
+func launchTests(sched *scheduler.Scheduler, bus *events.Bus, logs *runLogs, alerts *alert.Manager, db dbpkg.CanaryStore, met *metrics.Node, vm *otto.Otto, config *canary.Config, configs []*js.TestConfig) {
 	for _, cfg := range configs {
-		go runTestForever(db, met, vm, cfg, cfg.Test())
+		go runTestForever(sched, bus, logs, alerts, db, met, vm, cfg, cfg.Test())
 	}
 }
 
-func runTestForever(db dbpkg.CanaryStore, met *metrics.Node, vm *otto.Otto, cfg *js.TestConfig, test *js.Test) {
+func runTestForever(sched *scheduler.Scheduler, bus *events.Bus, logs *runLogs, alerts *alert.Manager, db dbpkg.CanaryStore, met *metrics.Node, vm *otto.Otto, cfg *js.TestConfig, test *js.Test) {
 	ll := log.WithFields(log.Fields{
 		"test.name": test.Name,
 	})
@@ -82,20 +121,26 @@ func runTestForever(db dbpkg.CanaryStore, met *metrics.Node, vm *otto.Otto, cfg
 
 	var (
 		started  = tmet.Counter("test_started_count", "Number of tests that were started")
-		finished = tmet.Counter("test_finished_count", "Number of tests that have finished", "result")
+		finished = tmet.Counter("test_finished_count", "Number of tests that have finished, by result and kind of failure", "result", "reason")
 		running  = tmet.Gauge("test_running_total", "Tests that are currently running")
+		lastRun  = tmet.Gauge("test_last_run_timestamp_seconds", "Time at which the test last finished running, in seconds since the epoch")
 		_        = tmet.Summary("test_duration_seconds", "Duration of tests", []float64{0.5, 0.75, 0.9, 0.99, 1.0}, "result")
+		sloMet   = newSLOMetrics(tmet, cfg.SLO)
 	)
-	for {
+	// We'll run the test each time it's due, per the frequency we defined.
+	sched.Every(test.Name, cfg.Frequency, func() {
 		go func(vm *otto.Otto) {
 			testID := uuid.New()
-			ll = ll.WithField("test.id", testID)
+			ll := ll.WithField("test.id", testID)
+			logs.begin(testID)
 
+			trips := newTripRecorder(http.DefaultTransport)
 			testCtx := &js.Context{
 				Log: ll,
 				HTTPClient: &http.Client{
-					Transport: http.DefaultTransport,
+					Transport: trips,
 				},
+				Steps: new(jscontext.Steps),
 			}
 
 			dbtest, err := db.StartTest(
@@ -105,6 +150,8 @@ func runTestForever(db dbpkg.CanaryStore, met *metrics.Node, vm *otto.Otto, cfg
 			)
 			if err != nil {
 				ll.WithError(err).Error("could not start the test")
+			} else {
+				bus.Publish(events.Event{Kind: events.RunStarted, Run: *dbtest})
 			}
 
 			// TODO: Add started and running counter calls here.
@@ -115,21 +162,98 @@ func runTestForever(db dbpkg.CanaryStore, met *metrics.Node, vm *otto.Otto, cfg
 			defer cancel()
 
 			terr := runner.Run(ctx, vm, testCtx, test, testID)
+			var failKind dbpkg.FailureKind
 			if terr != nil {
-				// TODO: Add finished counter calls here with pass/fail
-				finished.With(prometheus.Labels{"result": "fail"}).Add(1)
-				ll.WithError(terr).Error("test failed")
+				failKind = dbpkg.FailScriptError
+				if f, ok := terr.(*runner.Failure); ok {
+					failKind = f.Kind
+				}
+				finished.With(prometheus.Labels{"result": "fail", "reason": string(failKind)}).Add(1)
+				ll.WithError(terr).WithField("fail_kind", failKind).Error("test failed")
 			} else {
-				finished.With(prometheus.Labels{"result": "pass"}).Add(1)
+				finished.With(prometheus.Labels{"result": "pass", "reason": ""}).Add(1)
 			}
 
-			if err := db.EndTest(dbtest, terr, time.Now()); err != nil {
+			endAt := time.Now()
+			lastRun.Set(float64(endAt.UnixNano()) / 1e9)
+			dbtest.Logs = logs.end(testID)
+			dbtest.Steps = testCtx.Steps.List()
+			dbtest.HTTPTrips = trips.list()
+			dbtest.FailKind = failKind
+			if err := db.EndTest(dbtest, terr, endAt); err != nil {
 				ll.WithError(err).Error("couldn't mark test as being ended")
+			} else {
+				run := *dbtest
+				run.EndAt = endAt
+				run.Pass = terr == nil
+				if terr != nil {
+					run.FailCause = terr.Error()
+					run.FailKind = failKind
+				}
+				bus.Publish(events.Event{Kind: events.RunFinished, Run: run})
+				alerts.Observe(run)
+			}
+
+			if cfg.SLO != nil {
+				report, err := slo.Compute(db, test.Name, *cfg.SLO, time.Now())
+				if err != nil {
+					ll.WithError(err).Error("can't compute the SLO of the test")
+				} else {
+					sloMet.set(report)
+				}
 			}
 		}(vm.Copy()) // copy VM to avoid polluting global namespace
+	})
+}
+
+type sloMetrics struct {
+	successRatio    *prometheus.GaugeVec
+	budgetRemaining prometheus.Gauge
+	burnRate        *prometheus.GaugeVec
+}
+
+func newSLOMetrics(tmet *metrics.Node, objective *js.SLO) *sloMetrics {
+	if objective == nil {
+		return nil
+	}
+	target := tmet.Gauge("test_slo_target_ratio", "Share of the runs of the test that should pass")
+	target.Set(objective.Target / 100)
+	return &sloMetrics{
+		successRatio:    tmet.GaugeVec("test_slo_success_ratio", "Share of the runs of the test that passed over a window", "window"),
+		budgetRemaining: tmet.Gauge("test_slo_error_budget_remaining_ratio", "Share of the error budget left over the window of the SLO"),
+		burnRate:        tmet.GaugeVec("test_slo_burn_rate", "Rate at which the error budget was spent over a window", "window"),
+	}
+}
 
-		// We'll run the test again after the duration we defined.
-		time.Sleep(cfg.Frequency)
+func (met *sloMetrics) set(report *slo.Report) {
+	met.successRatio.WithLabelValues(slo.FormatWindow(report.Objective.Window)).Set(report.SuccessRatio)
+	met.budgetRemaining.Set(report.ErrorBudgetRemaining)
+	for _, br := range report.BurnRates {
+		window := slo.FormatWindow(br.Window)
+		met.successRatio.WithLabelValues(window).Set(br.SuccessRatio)
+		met.burnRate.WithLabelValues(window).Set(br.Rate)
+	}
+}
+
+// pruneForever deletes the runs and rollups that are older than they should
+// be retained for. A retention of 0 keeps them forever.
+func pruneForever(db dbpkg.CanaryStore, runsTTL, rollupsTTL time.Duration) {
+	if runsTTL == 0 && rollupsTTL == 0 {
+		return
+	}
+	for {
+		var runsBefore, rollupsBefore time.Time
+		now := time.Now()
+		if runsTTL != 0 {
+			runsBefore = now.Add(-runsTTL)
+		}
+		if rollupsTTL != 0 {
+			rollupsBefore = now.Add(-rollupsTTL)
+		}
+		if err := db.Prune(runsBefore, rollupsBefore); err != nil {
+			log.WithError(err).Error("can't prune database")
+		}
+		time.Sleep(time.Hour)
 	}
 }
 
@@ -148,43 +272,120 @@ func mustLoadConfigs(vm *otto.Otto, filename string) (*canary.Config, []*js.Test
 	return canaryConfig, testCfgs
 }
 
-func mustListen(host, port string) net.Listener {
+// mustSchedule creates a scheduler that honours the pauses kept in the store.
+func mustSchedule(db dbpkg.CanaryStore) *scheduler.Scheduler {
+	pauses, err := db.ListPauses()
+	if err != nil {
+		log.WithError(err).Fatal("can't list paused tests")
+	}
+	sched := scheduler.New()
+	for _, pause := range pauses {
+		sched.Pause(pause.TestName, pause.Until)
+	}
+	return sched
+}
+
+// mustListen listens over TLS when given a certificate. The TLS files are
+// reloaded as they change.
+func mustListen(host, port, certFile, keyFile, clientCAFile string) net.Listener {
+	if (certFile == "") != (keyFile == "") {
+		log.Fatal("tls.cert and tls.key must be given together")
+	}
+	if clientCAFile != "" && certFile == "" {
+		log.Fatal("tls.client-ca requires tls.cert and tls.key")
+	}
 	addr := net.JoinHostPort(host, port)
 	l, err := net.Listen("tcp", addr)
 	if err != nil {
 		log.WithError(err).Fatal("can't create listener")
 	}
-	return l
+	if certFile == "" {
+		return l
+	}
+	files, err := newTLSFiles(certFile, keyFile, clientCAFile)
+	if err != nil {
+		log.WithError(err).Fatal("can't load TLS files")
+	}
+	return tls.NewListener(l, files.Config())
 }
 
-func mustOpenBolt(path string) dbpkg.CanaryStore {
-	db, err := dbpkg.NewBoltStore(path)
+// mustAlert creates the alert manager, which picks up from the alert states
+// kept in the store, and records the deliveries of alerts there.
+func mustAlert(db dbpkg.CanaryStore, canaryCfg *canary.Config, testCfgs []*js.TestConfig, extURL string) *alert.Manager {
+	ll := log.WithField("component", "alert")
+	deliveries := alert.TeeDeliveries(alert.LogDeliveries(ll), alert.StoreDeliveries(db, ll))
+	alerts, err := alert.NewManager(db, canaryCfg.Name, extURL, testCfgs, canaryCfg.Notifiers, canaryCfg.Route, deliveries, canaryCfg.Maintenance)
 	if err != nil {
-		log.WithError(err).Fatal("can't open database")
+		log.WithError(err).Fatal("can't set up alerting")
+	}
+	return alerts
+}
+
+// mustExternalURL guesses the URL at which the canary is reached, from the
+// hostname and the port on which it listens.
+func mustExternalURL(l net.Listener, tls bool) string {
+	host, err := os.Hostname()
+	if err != nil {
+		log.WithError(err).Fatal("can't get hostname")
+	}
+	scheme := "http"
+	if tls {
+		scheme = "https"
+	}
+	port := strconv.Itoa(l.Addr().(*net.TCPAddr).Port)
+	return scheme + "://" + net.JoinHostPort(host, port)
+}
+
+func mustOpenStore(driver, path string) dbpkg.CanaryStore {
+	db, err := dbpkg.Open(driver, path)
+	if err != nil {
+		log.WithError(err).WithField("db.driver", driver).Fatal("can't open database")
 	}
 
 	return db
 }
 
+// mustLoadAuth reads the auth config from the file if one is given, or else
+// from the settings of the canary.
+func mustLoadAuth(canaryCfg *canary.Config, path string) *auth.Config {
+	if path == "" {
+		if canaryCfg.Auth != nil {
+			return canaryCfg.Auth
+		}
+		return new(auth.Config)
+	}
+	cfg, err := auth.Load(path)
+	if err != nil {
+		log.WithError(err).WithField("auth.file", path).Fatal("can't load auth config")
+	}
+	return cfg
+}
+
 func launchHTTP(
 	ctx context.Context,
 	l net.Listener,
 	promhdl http.Handler,
 	db dbpkg.CanaryStore,
+	bus *events.Bus,
+	sched *scheduler.Scheduler,
+	canaryCfg *canary.Config,
+	testCfgs []*js.TestConfig,
+	authCfg *auth.Config,
+	guard *auth.Guard,
 ) error {
-	addr := l.Addr().(*net.TCPAddr)
-	host, err := os.Hostname()
-	if err != nil {
-		return fmt.Errorf("can't get hostname: %v", err)
-	}
-	host = net.JoinHostPort(host, strconv.Itoa(addr.Port))
-
-	r := mux.NewRouter().Host(host).Subrouter()
+	// The routes match any host, so that probes and clients can reach the
+	// canary by IP, localhost or a service name.
+	r := mux.NewRouter()
+	r.Use(guard.Authenticate)
 
-	_ = app.New(db, r)
-	r.PathPrefix("/metrics").Handler(promhdl)
+	_ = app.New(db, bus, sched, canaryCfg, testCfgs, r)
+	if authCfg.PublicMetrics {
+		r.PathPrefix("/metrics").Handler(promhdl)
+	} else {
+		r.PathPrefix("/metrics").Handler(auth.Require(auth.Read, promhdl))
+	}
 
-	log.WithField("host", host).Info("API starting")
+	log.WithField("addr", l.Addr().String()).Info("API starting")
 	go http.Serve(l, r)
 	return nil
 }
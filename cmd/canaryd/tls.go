@@ -0,0 +1,130 @@
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// tlsReloadInterval is how often the TLS files are checked for changes.
+const tlsReloadInterval = 10 * time.Second
+
+// tlsFiles serves the certificate and client CAs found in files, reloading
+// them when the files change so that they can be rotated without a restart.
+type tlsFiles struct {
+	certFile, keyFile, clientCAFile string
+
+	mu      sync.Mutex
+	checked time.Time
+	modTime map[string]time.Time
+	config  *tls.Config
+}
+
+// newTLSFiles loads the files for the first time. The client CA file is
+// optional; when given, clients must present a certificate signed by one of
+// its CAs.
+func newTLSFiles(certFile, keyFile, clientCAFile string) (*tlsFiles, error) {
+	f := &tlsFiles{
+		certFile:     certFile,
+		keyFile:      keyFile,
+		clientCAFile: clientCAFile,
+	}
+	modTime, err := f.stat()
+	if err != nil {
+		return nil, err
+	}
+	if err := f.load(modTime); err != nil {
+		return nil, err
+	}
+	f.checked = time.Now()
+	return f, nil
+}
+
+// Config returns the TLS config of the listener.
+func (f *tlsFiles) Config() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			return f.current(), nil
+		},
+	}
+}
+
+// current returns the config for the files as they are now, reloading them if
+// they changed since they were last checked.
+func (f *tlsFiles) current() *tls.Config {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if time.Since(f.checked) < tlsReloadInterval {
+		return f.config
+	}
+	f.checked = time.Now()
+
+	modTime, err := f.stat()
+	if err != nil {
+		log.WithError(err).Error("can't check TLS files, keeping the current ones")
+		return f.config
+	}
+	changed := false
+	for name, t := range modTime {
+		if !t.Equal(f.modTime[name]) {
+			changed = true
+		}
+	}
+	if !changed {
+		return f.config
+	}
+	if err := f.load(modTime); err != nil {
+		log.WithError(err).Error("can't reload TLS files, keeping the current ones")
+		return f.config
+	}
+	log.Info("TLS files reloaded")
+	return f.config
+}
+
+func (f *tlsFiles) stat() (map[string]time.Time, error) {
+	modTime := make(map[string]time.Time, 3)
+	for _, name := range []string{f.certFile, f.keyFile, f.clientCAFile} {
+		if name == "" {
+			continue
+		}
+		fi, err := os.Stat(name)
+		if err != nil {
+			return nil, err
+		}
+		modTime[name] = fi.ModTime()
+	}
+	return modTime, nil
+}
+
+func (f *tlsFiles) load(modTime map[string]time.Time) error {
+	cert, err := tls.LoadX509KeyPair(f.certFile, f.keyFile)
+	if err != nil {
+		return fmt.Errorf("can't load certificate: %v", err)
+	}
+	cfg := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{cert},
+	}
+	if f.clientCAFile != "" {
+		pem, err := ioutil.ReadFile(f.clientCAFile)
+		if err != nil {
+			return fmt.Errorf("can't read client CAs: %v", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("no certificate found in %s", f.clientCAFile)
+		}
+		cfg.ClientCAs = pool
+		cfg.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	f.config = cfg
+	f.modTime = modTime
+	return nil
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	dbpkg "github.com/iheanyi/simple-canary/internal/db"
+)
+
+// tripRecorder is a transport that records the HTTP requests of a run, so they
+// can be saved along with the run.
+type tripRecorder struct {
+	next  http.RoundTripper
+	mu    sync.Mutex
+	trips []dbpkg.HTTPTrip
+}
+
+func newTripRecorder(next http.RoundTripper) *tripRecorder {
+	return &tripRecorder{next: next}
+}
+
+func (tr *tripRecorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := tr.next.RoundTrip(req)
+	trip := dbpkg.HTTPTrip{
+		Method:   req.Method,
+		URL:      req.URL.Redacted(),
+		StartAt:  start.UTC(),
+		Duration: time.Since(start),
+	}
+	if err != nil {
+		trip.Error = err.Error()
+	} else {
+		trip.StatusCode = resp.StatusCode
+	}
+	tr.mu.Lock()
+	tr.trips = append(tr.trips, trip)
+	tr.mu.Unlock()
+	return resp, err
+}
+
+// list returns the requests recorded so far.
+func (tr *tripRecorder) list() []dbpkg.HTTPTrip {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+	trips := make([]dbpkg.HTTPTrip, len(tr.trips))
+	copy(trips, tr.trips)
+	return trips
+}
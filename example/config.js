@@ -1,7 +1,104 @@
 settings({
   name: 'Example Canary',
+  // Without tokens or users, anyone can see the canary and nobody can
+  // administer it. For example, to require credentials except for metrics:
+  //
+  // auth: {
+  //   public_metrics: true,
+  //   tokens: [{ name: 'ci', token: 's3cret', role: 'admin' }],
+  //   users: [{ name: 'ops', password: 'hunter2', role: 'read' }],
+  // },
+  //
+  // Alerts go to all the notifiers, unless a route picks them. Alerts go down
+  // the first child route matching their test by tests or labels, then on to
+  // the next matching ones while continue is set. When no child matches, the
+  // notifiers of the route get them, which default to those of its parent, or
+  // to all the notifiers for the root route.
+  //
+  // route: {
+  //   notifiers: ['ops'],
+  //   routes: [
+  //     { labels: { team: 'payments' }, notifiers: ['payments'], continue: true },
+  //     { labels: { severity: 'page' }, notifiers: ['pager'] },
+  //   ],
+  // },
+  //
+  // Notifications about the tests matching the glob in tests, if any, and
+  // having all the labels are held back during maintenance windows. They
+  // start at the given time on the given days, every day by default.
+  //
+  // maintenance: [
+  //   {
+  //     name: 'deploys',
+  //     labels: { team: 'web' },
+  //     days: ['tue', 'thu'],
+  //     at: '14:00',
+  //     duration: '1h',
+  //     timezone: 'America/New_York',
+  //   },
+  // ],
 });
 
+// Alerts are posted to the webhooks declared with notifier(). When given a
+// secret, the body is signed with it, see X-Canary-Signature.
+//
+// notifier({
+//   name: 'ops',
+//   type: 'webhook',
+//   url: 'https://hooks.example.com/canary',
+//   secret: 's3cret',
+// });
+//
+// Slack and Teams incoming webhooks get messages whose title and text are Go
+// templates of the alert, which can be overridden.
+//
+// notifier({
+//   name: 'oncall',
+//   type: 'slack', // or 'teams'
+//   url: 'https://hooks.slack.com/services/...',
+//   templates: {
+//     title: '[{{.Status}}] {{.TestName}} after {{duration .Duration}}',
+//     text: '{{excerpt 100 .FailCause}}',
+//   },
+// });
+//
+// Emails are sent in plain text and HTML, from the templates subject, text and
+// html. With a digest, a summary of the failures is sent daily instead, from
+// digest_subject, digest_text and digest_html.
+//
+// notifier({
+//   name: 'mail',
+//   type: 'smtp',
+//   host: 'smtp.example.com',
+//   port: 587,
+//   starttls: 'always', // or 'if_available', 'never' (only to localhost with a username)
+//   username: 'canary',
+//   password: 's3cret',
+//   from: 'canary@example.com',
+//   to: ['ops@example.com'],
+//   recipients: { 'http demonstration': ['web@example.com'] },
+//   digest: { at: '09:00', timezone: 'Europe/Paris' },
+// });
+//
+// PagerDuty and Opsgenie get an incident per test, triggered when it fails and
+// resolved when it recovers. Their url defaults to that of their API.
+//
+// notifier({
+//   name: 'pager',
+//   type: 'pagerduty',
+//   routing_key: '...',
+//   severity: 'error', // critical by default
+//   severities: { 'http demonstration': 'critical' },
+// });
+//
+// notifier({
+//   name: 'genie',
+//   type: 'opsgenie',
+//   api_key: '...',
+//   priority: 'P3', // P1 by default
+//   priorities: { 'http demonstration': 'P1' },
+// });
+
 var frequency = '10m';
 var timeout = '10m';
 
@@ -19,6 +116,9 @@ register_test(
     name: 'http demonstration',
     frequency: frequency,
     timeout: timeout,
+    // Labels select tests in alert routes, silences and maintenance windows.
+    // Their names are those of Prometheus labels, like service_name.
+    labels: { team: 'web', service: 'homepage' },
   },
   file('simple-http.js')
 );
@@ -28,6 +128,19 @@ register_test(
     name: 'always pass',
     frequency: frequency,
     timeout: timeout,
+    slo: { target: 99.9, window: '30d' },
+    // Alert after 3 failures in a row, or 5 within an hour, and resolve
+    // after 2 passes in a row. Notifications are held back while the alert
+    // fires or resolves 4 times within 2 hours. Without an alert rule, a
+    // test alerts on its first failure and resolves on its first pass.
+    alert: {
+      consecutive_failures: 3,
+      failures: 5,
+      window: '1h',
+      consecutive_passes: 2,
+      flap_changes: 4,
+      flap_window: '2h',
+    },
   },
   file('always-pass.js')
 );
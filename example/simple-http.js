@@ -1,2 +1,5 @@
-var response = http.do('GET', 'https://icanhazip.com', {});
-log.kv('body', response.body).info('this is the test output for ip stuff');
+// Steps name the parts of a test, so that its runs tell which part failed.
+step('fetch ip', function() {
+  var response = http.do('GET', 'https://icanhazip.com', {});
+  log.kv('body', response.body).info('this is the test output for ip stuff');
+});
@@ -0,0 +1,97 @@
+// Package alert tells people about the tests that fail, through notifiers
+// such as webhooks.
+package alert
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// The statuses of alerts.
+const (
+	Firing   = "firing"
+	Resolved = "resolved"
+)
+
+// An Alert tells that a test started failing, or that it recovered, as of one
+// of its runs.
+type Alert struct {
+	Status    string `json:"status"`
+	Canary    string `json:"canary"`
+	TestName  string `json:"test_name"`
+	RunID     string `json:"run_id"`
+	FailCause string `json:"fail_cause"`
+	// FailKind classifies the failure, such as timeout or dns, telling the
+	// outages of the target apart from the failures of the canary.
+	FailKind string `json:"fail_kind,omitempty"`
+	// FailedStep is the name of the first step of the run that failed, if
+	// any.
+	FailedStep string    `json:"failed_step,omitempty"`
+	StartAt    time.Time `json:"start_at"`
+	EndAt      time.Time `json:"end_at"`
+	// URL links to the page of the run.
+	URL string `json:"url"`
+}
+
+// Duration is how long the run took.
+func (a *Alert) Duration() time.Duration {
+	return a.EndAt.Sub(a.StartAt)
+}
+
+// A Notifier delivers alerts somewhere people will see them.
+type Notifier interface {
+	// Name identifies the notifier in the config and in the delivery log.
+	Name() string
+	// Notify makes a single attempt at delivering the alert. Errors are
+	// retried, unless they're permanent.
+	Notify(ctx context.Context, alert *Alert) error
+}
+
+type permanentError struct{ err error }
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent marks an error that retrying won't fix.
+func Permanent(err error) error {
+	return &permanentError{err: err}
+}
+
+func isPermanent(err error) bool {
+	var p *permanentError
+	return errors.As(err, &p)
+}
+
+// ParseNotifier creates a notifier from its JSON config, whose type key tells
+// which kind of notifier it is.
+func ParseNotifier(data []byte) (Notifier, error) {
+	var head struct {
+		Name string `json:"name"`
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(data, &head); err != nil {
+		return nil, fmt.Errorf("can't parse notifier: %v", err)
+	}
+	if head.Name == "" {
+		return nil, fmt.Errorf("notifier needs a name")
+	}
+	switch head.Type {
+	case "webhook":
+		return parseWebhook(head.Name, data)
+	case "slack":
+		return parseSlack(head.Name, data)
+	case "teams":
+		return parseTeams(head.Name, data)
+	case "smtp":
+		return parseSMTP(head.Name, data)
+	case "pagerduty":
+		return parsePagerDuty(head.Name, data)
+	case "opsgenie":
+		return parseOpsgenie(head.Name, data)
+	default:
+		return nil, fmt.Errorf("notifier %q has unknown type %q", head.Name, head.Type)
+	}
+}
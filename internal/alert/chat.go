@@ -0,0 +1,112 @@
+package alert
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"text/template"
+	"time"
+	"unicode/utf8"
+)
+
+// chatConfig is the config shared by the notifiers posting to chats.
+type chatConfig struct {
+	URL       string            `json:"url"`
+	Timeout   string            `json:"timeout"`
+	Templates map[string]string `json:"templates"`
+}
+
+// chatTemplates render the title and the text of the messages about alerts.
+type chatTemplates struct {
+	title *template.Template
+	text  *template.Template
+}
+
+var templateFuncs = template.FuncMap{
+	"excerpt":  excerpt,
+	"duration": formatDuration,
+}
+
+func parseChatConfig(kind, name string, data []byte, defaults map[string]string) (*chatConfig, *chatTemplates, time.Duration, error) {
+	cfg := new(chatConfig)
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return nil, nil, 0, fmt.Errorf("can't parse %s notifier %q: %v", kind, name, err)
+	}
+	if u, err := url.Parse(cfg.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return nil, nil, 0, fmt.Errorf("%s notifier %q needs an http or https url, not %q", kind, name, cfg.URL)
+	}
+	timeout := 10 * time.Second
+	if cfg.Timeout != "" {
+		var err error
+		if timeout, err = time.ParseDuration(cfg.Timeout); err != nil {
+			return nil, nil, 0, fmt.Errorf("%s notifier %q has an invalid timeout: %v", kind, name, err)
+		}
+	}
+	tmpls, err := parseTemplates(name, defaults, cfg.Templates)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	return cfg, tmpls, timeout, nil
+}
+
+func parseTemplates(name string, defaults, overrides map[string]string) (*chatTemplates, error) {
+	for key := range overrides {
+		if _, ok := defaults[key]; !ok {
+			return nil, fmt.Errorf("notifier %q has no template named %q", name, key)
+		}
+	}
+	parse := func(key string) (*template.Template, error) {
+		src, ok := overrides[key]
+		if !ok {
+			src = defaults[key]
+		}
+		t, err := template.New(key).Funcs(templateFuncs).Parse(src)
+		if err != nil {
+			return nil, fmt.Errorf("can't parse template %q of notifier %q: %v", key, name, err)
+		}
+		return t, nil
+	}
+	title, err := parse("title")
+	if err != nil {
+		return nil, err
+	}
+	text, err := parse("text")
+	if err != nil {
+		return nil, err
+	}
+	return &chatTemplates{title: title, text: text}, nil
+}
+
+func (t *chatTemplates) render(alert *Alert) (title, text string, err error) {
+	var buf bytes.Buffer
+	if err := t.title.Execute(&buf, alert); err != nil {
+		return "", "", Permanent(fmt.Errorf("can't render title: %v", err))
+	}
+	title = strings.TrimSpace(buf.String())
+	buf.Reset()
+	if err := t.text.Execute(&buf, alert); err != nil {
+		return "", "", Permanent(fmt.Errorf("can't render text: %v", err))
+	}
+	return title, strings.TrimSpace(buf.String()), nil
+}
+
+// formatDuration rounds the duration to a precision fit for people.
+func formatDuration(d time.Duration) string {
+	if d < time.Millisecond {
+		return d.Round(time.Microsecond).String()
+	}
+	return d.Round(time.Millisecond).String()
+}
+
+// excerpt returns the first line of s, cut to at most n characters.
+func excerpt(n int, s string) string {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		s = s[:i]
+	}
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n-1]) + "…"
+}
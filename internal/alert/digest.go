@@ -0,0 +1,209 @@
+package alert
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/iheanyi/simple-canary/internal/db"
+	"github.com/pborman/uuid"
+)
+
+// A digester gathers the runs of tests, to notify about them on its own
+// schedule rather than alert by alert.
+type digester interface {
+	observeRun(run *db.TestInstance)
+	// queueAlert accounts for the alert in the next digest rather than
+	// delivering it, telling whether the digester is in digest mode.
+	queueAlert(alert *Alert) bool
+	// sendDigests sends the digests when they're due, forever.
+	sendDigests(canary string, deliveries DeliveryLog)
+}
+
+// Digest sums up the runs of tests over a period. It's what the digest
+// templates are executed with.
+type Digest struct {
+	Canary   string
+	From, To time.Time
+	Failures int
+	Tests    []*DigestTest
+}
+
+// DigestTest sums up the runs of a test in a digest.
+type DigestTest struct {
+	Name          string
+	Runs          int
+	Failures      int
+	Fired         int
+	LastFailCause string
+}
+
+// digest accumulates the runs of tests until it's sent.
+type digest struct {
+	mu    sync.Mutex
+	from  time.Time
+	tests map[string]*DigestTest
+}
+
+func newDigest(from time.Time) *digest {
+	return &digest{from: from, tests: make(map[string]*DigestTest)}
+}
+
+func (d *digest) test(name string) *DigestTest {
+	t, ok := d.tests[name]
+	if !ok {
+		t = &DigestTest{Name: name}
+		d.tests[name] = t
+	}
+	return t
+}
+
+func (d *digest) addRun(run *db.TestInstance) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	t := d.test(run.TestName)
+	t.Runs++
+	if !run.Pass {
+		t.Failures++
+		t.LastFailCause = run.FailCause
+	}
+}
+
+func (d *digest) addAlert(alert *Alert) {
+	if alert.Status != Firing {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.test(alert.TestName).Fired++
+}
+
+// take returns what was accumulated since the last time, sorted by test.
+func (d *digest) take(now time.Time) (time.Time, []*DigestTest) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	from := d.from
+	tests := make([]*DigestTest, 0, len(d.tests))
+	for _, t := range d.tests {
+		tests = append(tests, t)
+	}
+	sort.Slice(tests, func(i, j int) bool { return tests[i].Name < tests[j].Name })
+	d.from = now
+	d.tests = make(map[string]*DigestTest)
+	return from, tests
+}
+
+func (n *SMTP) parseDigest(at, timezone string) error {
+	t, err := time.Parse("15:04", at)
+	if err != nil {
+		return fmt.Errorf("smtp notifier %q needs the digest at a time like 09:00, not %q", n.name, at)
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return fmt.Errorf("smtp notifier %q has an unknown digest timezone: %v", n.name, err)
+	}
+	n.digestAt = time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+	n.digestLoc = loc
+	n.digest = newDigest(time.Now())
+	return nil
+}
+
+// nextDigest returns when the digest is next due after now.
+func (n *SMTP) nextDigest(now time.Time) time.Time {
+	now = now.In(n.digestLoc)
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, n.digestLoc)
+	next := day.Add(n.digestAt)
+	if !next.After(now) {
+		next = day.AddDate(0, 0, 1).Add(n.digestAt)
+	}
+	return next
+}
+
+func (n *SMTP) observeRun(run *db.TestInstance) {
+	if n.digest != nil {
+		n.digest.addRun(run)
+	}
+}
+
+func (n *SMTP) queueAlert(alert *Alert) bool {
+	if n.digest == nil {
+		return false
+	}
+	n.digest.addAlert(alert)
+	return true
+}
+
+func (n *SMTP) sendDigests(canary string, deliveries DeliveryLog) {
+	if n.digest == nil {
+		return
+	}
+	for {
+		time.Sleep(time.Until(n.nextDigest(time.Now())))
+		now := time.Now()
+		from, tests := n.digest.take(now)
+
+		// Each recipient gets the digest of the tests they'd be alerted
+		// about.
+		byRecipients := make(map[string][]*DigestTest)
+		for _, t := range tests {
+			key := strings.Join(n.recipients(t.Name), ",")
+			byRecipients[key] = append(byRecipients[key], t)
+		}
+		for key, tests := range byRecipients {
+			if key == "" {
+				continue
+			}
+			dg := &Digest{Canary: canary, From: from, To: now, Tests: tests}
+			for _, t := range tests {
+				dg.Failures += t.Failures
+			}
+			to := strings.Split(key, ",")
+			d := Delivery{ID: uuid.New(), Notifier: n.name}
+			go retry(d, newDigestDeliveries(deliveries, tests), func(ctx context.Context) error {
+				return n.sendDigest(ctx, to, dg)
+			})
+		}
+	}
+}
+
+// digestDeliveries records each attempt at sending a digest as one for each
+// of its tests, so that they show up in the history of the tests. The tests
+// get their own delivery IDs, which are unique to the test in the store.
+type digestDeliveries struct {
+	next  DeliveryLog
+	tests []*DigestTest
+	ids   []string
+}
+
+func newDigestDeliveries(next DeliveryLog, tests []*DigestTest) *digestDeliveries {
+	ids := make([]string, len(tests))
+	for i := range ids {
+		ids[i] = uuid.New()
+	}
+	return &digestDeliveries{next: next, tests: tests, ids: ids}
+}
+
+func (dd *digestDeliveries) Record(d Delivery) {
+	for i, t := range dd.tests {
+		d.ID, d.TestName = dd.ids[i], t.Name
+		dd.next.Record(d)
+	}
+}
+
+func (n *SMTP) sendDigest(ctx context.Context, to []string, dg *Digest) error {
+	var subject, text, html bytes.Buffer
+	if err := n.digestSubject.Execute(&subject, dg); err != nil {
+		return Permanent(fmt.Errorf("can't render digest subject: %v", err))
+	}
+	if err := n.digestText.Execute(&text, dg); err != nil {
+		return Permanent(fmt.Errorf("can't render digest text: %v", err))
+	}
+	if err := n.digestHTML.Execute(&html, dg); err != nil {
+		return Permanent(fmt.Errorf("can't render digest html: %v", err))
+	}
+	return n.send(ctx, to, subject.String(), text.Bytes(), html.Bytes())
+}
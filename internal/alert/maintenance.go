@@ -0,0 +1,121 @@
+package alert
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/iheanyi/simple-canary/internal/db"
+)
+
+// Maintenance is a recurring window during which the notifications about the
+// tests it matches are held back, such as while deploys happen.
+type Maintenance struct {
+	Name string
+	db.TestMatcher
+	// Days are the days on which the window starts, every day if empty.
+	Days     []time.Weekday
+	At       time.Duration
+	Duration time.Duration
+	Location *time.Location
+}
+
+type maintenanceConfig struct {
+	Name     string            `json:"name"`
+	Tests    string            `json:"tests"`
+	Labels   map[string]string `json:"labels"`
+	Days     []string          `json:"days"`
+	At       string            `json:"at"`
+	Duration string            `json:"duration"`
+	Timezone string            `json:"timezone"`
+}
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// ParseMaintenance reads the JSON list of the maintenance windows.
+func ParseMaintenance(data []byte) ([]*Maintenance, error) {
+	var cfgs []maintenanceConfig
+	if err := json.Unmarshal(data, &cfgs); err != nil {
+		return nil, fmt.Errorf("can't parse maintenance windows: %v", err)
+	}
+	windows := make([]*Maintenance, 0, len(cfgs))
+	for i, cfg := range cfgs {
+		if cfg.Name == "" {
+			return nil, fmt.Errorf("maintenance window %d needs a name", i)
+		}
+		if cfg.Tests == "" && len(cfg.Labels) == 0 {
+			return nil, fmt.Errorf("maintenance window %q needs tests or labels to match", cfg.Name)
+		}
+		if _, err := path.Match(cfg.Tests, ""); err != nil {
+			return nil, fmt.Errorf("maintenance window %q has invalid tests %q: %v", cfg.Name, cfg.Tests, err)
+		}
+		w := &Maintenance{
+			Name:        cfg.Name,
+			TestMatcher: db.TestMatcher{Tests: cfg.Tests, Labels: cfg.Labels},
+		}
+		for _, day := range cfg.Days {
+			wd, ok := weekdays[strings.ToLower(day)]
+			if !ok {
+				return nil, fmt.Errorf("maintenance window %q has unknown day %q, should be one of: mon, tue, wed, thu, fri, sat, sun", cfg.Name, day)
+			}
+			w.Days = append(w.Days, wd)
+		}
+		at, err := time.Parse("15:04", cfg.At)
+		if err != nil {
+			return nil, fmt.Errorf("maintenance window %q needs to start at a time like 02:00, not %q", cfg.Name, cfg.At)
+		}
+		w.At = time.Duration(at.Hour())*time.Hour + time.Duration(at.Minute())*time.Minute
+		if w.Duration, err = time.ParseDuration(cfg.Duration); err != nil {
+			return nil, fmt.Errorf("maintenance window %q has an invalid duration: %v", cfg.Name, err)
+		}
+		if w.Duration <= 0 || w.Duration > 7*24*time.Hour {
+			return nil, fmt.Errorf("maintenance window %q must last between 0 and 7 days, not %v", cfg.Name, w.Duration)
+		}
+		if w.Location, err = time.LoadLocation(cfg.Timezone); err != nil {
+			return nil, fmt.Errorf("maintenance window %q has an unknown timezone: %v", cfg.Name, err)
+		}
+		windows = append(windows, w)
+	}
+	return windows, nil
+}
+
+// Active tells whether the window is open at the given time.
+func (w *Maintenance) Active(now time.Time) bool {
+	now = now.In(w.Location)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, w.Location)
+	// Windows last at most a week, so the one in effect started within
+	// the last 7 days.
+	for i := 0; i <= 7; i++ {
+		day := today.AddDate(0, 0, -i)
+		if !w.startsOn(day.Weekday()) {
+			continue
+		}
+		start := day.Add(w.At)
+		if !now.Before(start) && now.Before(start.Add(w.Duration)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (w *Maintenance) startsOn(day time.Weekday) bool {
+	if len(w.Days) == 0 {
+		return true
+	}
+	for _, d := range w.Days {
+		if d == day {
+			return true
+		}
+	}
+	return false
+}
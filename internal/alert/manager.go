@@ -0,0 +1,440 @@
+package alert
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/iheanyi/simple-canary/internal/db"
+	"github.com/iheanyi/simple-canary/internal/js"
+	"github.com/pborman/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// Deliveries are attempted up to maxAttempts times, waiting twice as long
+// after each failed attempt, starting with firstBackoff.
+const (
+	maxAttempts    = 5
+	firstBackoff   = time.Second
+	attemptTimeout = 30 * time.Second
+)
+
+// A Delivery is an attempt at delivering an alert through a notifier.
+type Delivery struct {
+	// ID is shared by the attempts at delivering the same alert through
+	// the same notifier.
+	ID       string
+	Notifier string
+	TestName string
+	RunID    string
+	Attempt  int
+	At       time.Time
+	Latency  time.Duration
+	// StatusCode is that of the response of the notified service, if
+	// it got that far.
+	StatusCode int
+	// Err is nil if the alert was delivered.
+	Err error
+	// Final is true if no more attempts will be made.
+	Final bool
+	// Silenced tells what held the alert back, such as a silence or the
+	// digest it's left to, in which case no attempt was made.
+	Silenced string
+}
+
+// A DeliveryLog keeps track of the attempts at delivering alerts.
+type DeliveryLog interface {
+	Record(d Delivery)
+}
+
+type logDeliveries struct {
+	l logrus.FieldLogger
+}
+
+// LogDeliveries records the deliveries in the logs.
+func LogDeliveries(l logrus.FieldLogger) DeliveryLog {
+	return &logDeliveries{l: l}
+}
+
+func (dl *logDeliveries) Record(d Delivery) {
+	ll := dl.l.WithFields(logrus.Fields{
+		"delivery.id": d.ID,
+		"notifier":    d.Notifier,
+		"test.name":   d.TestName,
+		"test.id":     d.RunID,
+		"attempt":     d.Attempt,
+		"latency":     d.Latency.String(),
+	})
+	if d.StatusCode != 0 {
+		ll = ll.WithField("status_code", d.StatusCode)
+	}
+	switch {
+	case d.Silenced != "":
+		ll.WithField("silenced_by", d.Silenced).Info("alert silenced")
+	case d.Err == nil:
+		ll.Info("alert delivered")
+	case d.Final:
+		ll.WithError(d.Err).Error("can't deliver alert, giving up")
+	default:
+		ll.WithError(d.Err).Warn("can't deliver alert, will retry")
+	}
+}
+
+type storeDeliveries struct {
+	l     logrus.FieldLogger
+	store db.CanaryStore
+}
+
+// StoreDeliveries records the deliveries in the store, logging the errors in
+// doing so.
+func StoreDeliveries(store db.CanaryStore, l logrus.FieldLogger) DeliveryLog {
+	return &storeDeliveries{l: l, store: store}
+}
+
+func (dl *storeDeliveries) Record(d Delivery) {
+	attempt := db.DeliveryAttempt{
+		DeliveryID: d.ID,
+		Notifier:   d.Notifier,
+		TestName:   d.TestName,
+		RunID:      d.RunID,
+		Attempt:    d.Attempt,
+		At:         d.At,
+		Latency:    d.Latency,
+		StatusCode: d.StatusCode,
+		Final:      d.Final,
+		SilencedBy: d.Silenced,
+	}
+	if d.Err != nil {
+		attempt.Error = d.Err.Error()
+	}
+	if err := dl.store.AddDeliveryAttempt(attempt); err != nil {
+		dl.l.WithError(err).WithField("delivery.id", d.ID).Error("can't save delivery attempt")
+	}
+}
+
+type teeDeliveries []DeliveryLog
+
+// TeeDeliveries records the deliveries in each of the logs.
+func TeeDeliveries(logs ...DeliveryLog) DeliveryLog {
+	return teeDeliveries(logs)
+}
+
+func (logs teeDeliveries) Record(d Delivery) {
+	for _, l := range logs {
+		l.Record(d)
+	}
+}
+
+// A Manager keeps track of the alert state of tests as they run, and has its
+// notifiers tell when alerts start or stop firing.
+type Manager struct {
+	l          logrus.FieldLogger
+	db         db.CanaryStore
+	canary     string
+	baseURL    string
+	tests      map[string]*js.TestConfig
+	notifiers  []Notifier
+	route      *Route
+	deliveries DeliveryLog
+	windows    []*Maintenance
+
+	mu     sync.Mutex
+	states map[string]*db.AlertState
+	// queues holds the channel that the delivery last queued to each
+	// notifier about each test closes once done, for the next one to wait
+	// on. Alerts about a test thus reach each notifier in order.
+	queues map[queueKey]chan struct{}
+}
+
+type queueKey struct {
+	notifier, test string
+}
+
+// NewManager creates a manager alerting about the tests of the named canary,
+// whose pages are served under baseURL. It picks up from the alert states kept
+// in the store, and starts sending the digests of the notifiers that have
+// some. Alerts go to the notifiers picked by the route, or to all of them
+// without one. Notifications are held back during the maintenance windows, and
+// the silences kept in the store.
+func NewManager(
+	store db.CanaryStore,
+	canary, baseURL string,
+	tests []*js.TestConfig,
+	notifiers []Notifier,
+	route *Route,
+	deliveries DeliveryLog,
+	windows []*Maintenance,
+) (*Manager, error) {
+	states, err := store.ListAlertStates()
+	if err != nil {
+		return nil, fmt.Errorf("can't list alert states: %v", err)
+	}
+	m := &Manager{
+		l:          logrus.WithField("component", "alert"),
+		db:         store,
+		canary:     canary,
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		tests:      make(map[string]*js.TestConfig, len(tests)),
+		notifiers:  notifiers,
+		route:      route,
+		deliveries: deliveries,
+		windows:    windows,
+		states:     make(map[string]*db.AlertState, len(states)),
+		queues:     make(map[queueKey]chan struct{}),
+	}
+	for _, cfg := range tests {
+		m.tests[cfg.Name] = cfg
+	}
+	for i := range states {
+		m.states[states[i].TestName] = &states[i]
+	}
+	for _, n := range notifiers {
+		if d, ok := n.(digester); ok {
+			go d.sendDigests(canary, deliveries)
+		}
+	}
+	return m, nil
+}
+
+// Observe is fed the result of each run, once it has ended. It doesn't wait
+// for alerts to be delivered.
+func (m *Manager) Observe(run db.TestInstance) {
+	rule := js.DefaultAlertRule
+	if cfg, ok := m.tests[run.TestName]; ok {
+		rule = cfg.Alert
+	}
+	ll := m.l.WithFields(logrus.Fields{"test.name": run.TestName, "test.id": run.TestID})
+	notifiers := m.notifiersOf(run.TestName)
+	for _, n := range notifiers {
+		if d, ok := n.(digester); ok {
+			d.observeRun(&run)
+		}
+	}
+
+	m.mu.Lock()
+	state, ok := m.states[run.TestName]
+	if !ok {
+		state = &db.AlertState{TestName: run.TestName}
+		m.states[run.TestName] = state
+	}
+	wasFlapping := state.Flapping
+	changed := observe(state, rule, &run)
+	if changed {
+		ll.WithField("firing", state.Firing).Info("alert changed")
+	}
+	if state.Flapping != wasFlapping {
+		ll.WithField("flapping", state.Flapping).Warn("alert flapping changed")
+	}
+	// Notifications are held back while flapping or silenced, and the
+	// state that it settles on is notified after.
+	notify := !state.Flapping && state.Firing != state.Notified
+	var silencedBy string
+	if notify {
+		silencedBy = m.silencedBy(run.TestName, time.Now())
+		if silencedBy != "" {
+			notify = false
+		} else {
+			state.Notified = state.Firing
+		}
+	}
+	if err := m.db.PutAlertState(*state); err != nil {
+		ll.WithError(err).Error("can't save alert state")
+	}
+	if changed || state.Flapping != wasFlapping {
+		err := m.db.AddAlertTransition(db.AlertTransition{
+			TestName: run.TestName,
+			At:       run.EndAt,
+			RunID:    run.TestID,
+			Firing:   state.Firing,
+			Flapping: state.Flapping,
+		})
+		if err != nil {
+			ll.WithError(err).Error("can't save alert transition")
+		}
+	}
+	firing := state.Firing
+	var prevs, dones []chan struct{}
+	if notify {
+		for _, n := range notifiers {
+			key := queueKey{notifier: n.Name(), test: run.TestName}
+			done := make(chan struct{})
+			prevs = append(prevs, m.queues[key])
+			dones = append(dones, done)
+			m.queues[key] = done
+		}
+	}
+	m.mu.Unlock()
+
+	if silencedBy != "" && changed {
+		for _, n := range notifiers {
+			m.deliveries.Record(Delivery{
+				ID:       uuid.New(),
+				Notifier: n.Name(),
+				TestName: run.TestName,
+				RunID:    run.TestID,
+				At:       time.Now(),
+				Final:    true,
+				Silenced: silencedBy,
+			})
+		}
+	}
+	if !notify || len(notifiers) == 0 {
+		return
+	}
+	status := Resolved
+	if firing {
+		status = Firing
+	}
+	alert := &Alert{
+		Status:    status,
+		Canary:    m.canary,
+		TestName:  run.TestName,
+		RunID:     run.TestID,
+		FailCause: run.FailCause,
+		FailKind:  string(run.FailKind),
+		StartAt:   run.StartAt,
+		EndAt:     run.EndAt,
+		URL:       m.baseURL + "/status/runs/" + run.TestID,
+	}
+	for _, step := range run.Steps {
+		if !step.Pass {
+			alert.FailedStep = step.Name
+			break
+		}
+	}
+	for i, n := range notifiers {
+		go m.deliverAfter(prevs[i], dones[i], n, alert)
+	}
+}
+
+// notifiersOf returns the notifiers of the alerts about the named test.
+func (m *Manager) notifiersOf(name string) []Notifier {
+	if m.route == nil {
+		return m.notifiers
+	}
+	var labels map[string]string
+	if cfg, ok := m.tests[name]; ok {
+		labels = cfg.Labels
+	}
+	// A root route without notifiers sends to all of them, as when there's no
+	// route.
+	all := make([]string, len(m.notifiers))
+	for i, n := range m.notifiers {
+		all[i] = n.Name()
+	}
+	names, _ := m.route.route(name, labels, all)
+	picked := make(map[string]bool, len(names))
+	for _, name := range names {
+		picked[name] = true
+	}
+	notifiers := make([]Notifier, 0, len(picked))
+	for _, n := range m.notifiers {
+		if picked[n.Name()] {
+			notifiers = append(notifiers, n)
+		}
+	}
+	return notifiers
+}
+
+// silencedBy tells which maintenance window or silence holds back the
+// notifications about the named test at the given time, if any.
+func (m *Manager) silencedBy(name string, now time.Time) string {
+	var labels map[string]string
+	if cfg, ok := m.tests[name]; ok {
+		labels = cfg.Labels
+	}
+	for _, w := range m.windows {
+		if w.Active(now) && w.Matches(name, labels) {
+			return "maintenance " + w.Name
+		}
+	}
+	silences, err := m.db.ListSilences()
+	if err != nil {
+		// Better to notify too much than not at all.
+		m.l.WithError(err).Error("can't list silences")
+		return ""
+	}
+	for _, s := range silences {
+		if s.Active(now) && s.Matches(name, labels) {
+			return "silence " + s.ID
+		}
+	}
+	return ""
+}
+
+// deliverAfter delivers the alert once the previous delivery to the notifier
+// about the same test, if any, is done, then lets the next one go.
+func (m *Manager) deliverAfter(prev, done chan struct{}, n Notifier, alert *Alert) {
+	defer close(done)
+	if prev != nil {
+		<-prev
+	}
+	m.deliver(n, alert)
+}
+
+// deliver has the notifier deliver the alert, retrying with backoff.
+func (m *Manager) deliver(n Notifier, alert *Alert) {
+	d := Delivery{
+		ID:       uuid.New(),
+		Notifier: n.Name(),
+		TestName: alert.TestName,
+		RunID:    alert.RunID,
+	}
+	if dg, ok := n.(digester); ok && dg.queueAlert(alert) {
+		d.At = time.Now()
+		d.Final = true
+		d.Silenced = "digest"
+		m.deliveries.Record(d)
+		return
+	}
+	retry(d, m.deliveries, func(ctx context.Context) error {
+		return n.Notify(ctx, alert)
+	})
+}
+
+// retry attempts the delivery until it succeeds, fails permanently or runs out
+// of attempts, recording each attempt.
+func retry(d Delivery, deliveries DeliveryLog, attempt func(ctx context.Context) error) {
+	backoff := firstBackoff
+	for d.Attempt = 1; ; d.Attempt++ {
+		ctx, cancel := context.WithTimeout(withDeliveryID(context.Background(), d.ID), attemptTimeout)
+		d.StatusCode = 0
+		ctx = context.WithValue(ctx, statusCodeKey{}, &d.StatusCode)
+		d.At = time.Now()
+		d.Err = attempt(ctx)
+		d.Latency = time.Since(d.At)
+		cancel()
+
+		d.Final = d.Err == nil || isPermanent(d.Err) || d.Attempt == maxAttempts
+		deliveries.Record(d)
+		if d.Final {
+			return
+		}
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+type deliveryIDKey struct{}
+
+func withDeliveryID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, deliveryIDKey{}, id)
+}
+
+// deliveryID returns the ID of the delivery being attempted, which is the same
+// across retries so that receivers can tell them apart from new alerts.
+func deliveryID(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(deliveryIDKey{}).(string)
+	return id, ok
+}
+
+type statusCodeKey struct{}
+
+// setStatusCode records the status code of the response to the attempt.
+func setStatusCode(ctx context.Context, code int) {
+	if p, ok := ctx.Value(statusCodeKey{}).(*int); ok {
+		*p = code
+	}
+}
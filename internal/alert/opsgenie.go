@@ -0,0 +1,136 @@
+package alert
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const opsgenieURL = "https://api.opsgenie.com"
+
+// Opsgenie creates and closes Opsgenie alerts through the Alert API, one
+// alert per test.
+type Opsgenie struct {
+	name       string
+	url        string
+	apiKey     string
+	priority   string
+	priorities map[string]string
+	client     *http.Client
+}
+
+type opsgenieConfig struct {
+	// URL is that of the API, such as https://api.eu.opsgenie.com.
+	URL    string `json:"url"`
+	APIKey string `json:"api_key"`
+	// Priority is that of the tests that aren't in Priorities.
+	Priority   string            `json:"priority"`
+	Priorities map[string]string `json:"priorities"`
+	Timeout    string            `json:"timeout"`
+}
+
+func parseOpsgenie(name string, data []byte) (*Opsgenie, error) {
+	cfg := &opsgenieConfig{URL: opsgenieURL, Priority: "P1"}
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("can't parse opsgenie notifier %q: %v", name, err)
+	}
+	if u, err := url.Parse(cfg.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return nil, fmt.Errorf("opsgenie notifier %q needs an http or https url, not %q", name, cfg.URL)
+	}
+	if cfg.APIKey == "" {
+		return nil, fmt.Errorf("opsgenie notifier %q needs an api_key", name)
+	}
+	valid := func(priority string) bool {
+		switch priority {
+		case "P1", "P2", "P3", "P4", "P5":
+			return true
+		}
+		return false
+	}
+	if !valid(cfg.Priority) {
+		return nil, fmt.Errorf("opsgenie notifier %q has unknown priority %q, should be one of: P1, P2, P3, P4, P5", name, cfg.Priority)
+	}
+	for test, priority := range cfg.Priorities {
+		if !valid(priority) {
+			return nil, fmt.Errorf("opsgenie notifier %q has unknown priority %q for test %q, should be one of: P1, P2, P3, P4, P5", name, priority, test)
+		}
+	}
+	timeout := 10 * time.Second
+	if cfg.Timeout != "" {
+		var err error
+		if timeout, err = time.ParseDuration(cfg.Timeout); err != nil {
+			return nil, fmt.Errorf("opsgenie notifier %q has an invalid timeout: %v", name, err)
+		}
+	}
+	return &Opsgenie{
+		name:       name,
+		url:        strings.TrimSuffix(cfg.URL, "/"),
+		apiKey:     cfg.APIKey,
+		priority:   cfg.Priority,
+		priorities: cfg.Priorities,
+		client:     &http.Client{Timeout: timeout},
+	}, nil
+}
+
+// Name implements Notifier.
+func (og *Opsgenie) Name() string { return og.name }
+
+// Notify implements Notifier. Firing alerts create the Opsgenie alert of the
+// test, resolved ones close it.
+func (og *Opsgenie) Notify(ctx context.Context, alert *Alert) error {
+	headers := map[string]string{"Authorization": "GenieKey " + og.apiKey}
+	alias := alertKey(alert)
+	if alert.Status != Firing {
+		body, err := json.Marshal(opsgenieClose{
+			Source: alertSource(alert),
+			Note:   "The run " + alert.RunID + " passed: " + alert.URL,
+		})
+		if err != nil {
+			return Permanent(err)
+		}
+		u := og.url + "/v2/alerts/" + url.PathEscape(alias) + "/close?identifierType=alias"
+		return postJSON(ctx, og.client, u, headers, body)
+	}
+
+	priority, ok := og.priorities[alert.TestName]
+	if !ok {
+		priority = og.priority
+	}
+	body, err := json.Marshal(opsgenieAlert{
+		Message:     excerpt(130, alert.TestName+" is failing: "+alert.FailCause),
+		Alias:       alias,
+		Description: strings.TrimSpace(alert.FailCause) + "\n\n" + alert.URL,
+		Priority:    priority,
+		Source:      alertSource(alert),
+		Entity:      alert.TestName,
+		Details: map[string]string{
+			"run_id":      alert.RunID,
+			"run_url":     alert.URL,
+			"failed_step": alert.FailedStep,
+			"duration":    formatDuration(alert.Duration()),
+		},
+	})
+	if err != nil {
+		return Permanent(err)
+	}
+	return postJSON(ctx, og.client, og.url+"/v2/alerts", headers, body)
+}
+
+type opsgenieAlert struct {
+	Message     string            `json:"message"`
+	Alias       string            `json:"alias"`
+	Description string            `json:"description"`
+	Priority    string            `json:"priority"`
+	Source      string            `json:"source"`
+	Entity      string            `json:"entity"`
+	Details     map[string]string `json:"details"`
+}
+
+type opsgenieClose struct {
+	Source string `json:"source"`
+	Note   string `json:"note"`
+}
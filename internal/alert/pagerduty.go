@@ -0,0 +1,158 @@
+package alert
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+)
+
+const pagerDutyURL = "https://events.pagerduty.com/v2/enqueue"
+
+// PagerDuty triggers and resolves PagerDuty incidents through the Events API
+// v2, one incident per test.
+type PagerDuty struct {
+	name       string
+	url        string
+	routingKey string
+	severity   string
+	severities map[string]string
+	client     *http.Client
+}
+
+type pagerDutyConfig struct {
+	URL        string `json:"url"`
+	RoutingKey string `json:"routing_key"`
+	// Severity is that of the tests that aren't in Severities.
+	Severity   string            `json:"severity"`
+	Severities map[string]string `json:"severities"`
+	Timeout    string            `json:"timeout"`
+}
+
+func parsePagerDuty(name string, data []byte) (*PagerDuty, error) {
+	cfg := &pagerDutyConfig{URL: pagerDutyURL, Severity: "critical"}
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("can't parse pagerduty notifier %q: %v", name, err)
+	}
+	if u, err := url.Parse(cfg.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return nil, fmt.Errorf("pagerduty notifier %q needs an http or https url, not %q", name, cfg.URL)
+	}
+	if cfg.RoutingKey == "" {
+		return nil, fmt.Errorf("pagerduty notifier %q needs a routing_key", name)
+	}
+	valid := func(severity string) bool {
+		switch severity {
+		case "critical", "error", "warning", "info":
+			return true
+		}
+		return false
+	}
+	if !valid(cfg.Severity) {
+		return nil, fmt.Errorf("pagerduty notifier %q has unknown severity %q, should be one of: critical, error, warning, info", name, cfg.Severity)
+	}
+	for test, severity := range cfg.Severities {
+		if !valid(severity) {
+			return nil, fmt.Errorf("pagerduty notifier %q has unknown severity %q for test %q, should be one of: critical, error, warning, info", name, severity, test)
+		}
+	}
+	timeout := 10 * time.Second
+	if cfg.Timeout != "" {
+		var err error
+		if timeout, err = time.ParseDuration(cfg.Timeout); err != nil {
+			return nil, fmt.Errorf("pagerduty notifier %q has an invalid timeout: %v", name, err)
+		}
+	}
+	return &PagerDuty{
+		name:       name,
+		url:        cfg.URL,
+		routingKey: cfg.RoutingKey,
+		severity:   cfg.Severity,
+		severities: cfg.Severities,
+		client:     &http.Client{Timeout: timeout},
+	}, nil
+}
+
+// Name implements Notifier.
+func (pd *PagerDuty) Name() string { return pd.name }
+
+// Notify implements Notifier. Firing alerts trigger the incident of the test,
+// resolved ones resolve it.
+func (pd *PagerDuty) Notify(ctx context.Context, alert *Alert) error {
+	event := pagerDutyEvent{
+		RoutingKey: pd.routingKey,
+		Action:     "resolve",
+		DedupKey:   alertKey(alert),
+	}
+	if alert.Status == Firing {
+		severity, ok := pd.severities[alert.TestName]
+		if !ok {
+			severity = pd.severity
+		}
+		event.Action = "trigger"
+		event.Payload = &pagerDutyPayload{
+			Summary:   excerpt(1024, alert.TestName+" is failing: "+alert.FailCause),
+			Source:    alertSource(alert),
+			Severity:  severity,
+			Timestamp: alert.EndAt,
+			Component: alert.TestName,
+			Details: map[string]string{
+				"run_id":      alert.RunID,
+				"failed_step": alert.FailedStep,
+				"fail_cause":  alert.FailCause,
+				"duration":    formatDuration(alert.Duration()),
+			},
+		}
+		event.Links = []pagerDutyLink{{Href: alert.URL, Text: "View run"}}
+	}
+	body, err := json.Marshal(event)
+	if err != nil {
+		return Permanent(err)
+	}
+	return postJSON(ctx, pd.client, pd.url, nil, body)
+}
+
+// alertKey identifies the incidents about a test, so that its alerts trigger
+// and resolve the same one.
+func alertKey(alert *Alert) string {
+	return "simple-canary/" + alertSource(alert) + "/" + alert.TestName
+}
+
+// alertSource names the canary the alert comes from. Canaries without a name
+// go by the host of their URL, as the APIs paged require a source.
+func alertSource(alert *Alert) string {
+	if alert.Canary != "" {
+		return alert.Canary
+	}
+	if u, err := url.Parse(alert.URL); err == nil && u.Host != "" {
+		return u.Host
+	}
+	if host, err := os.Hostname(); err == nil {
+		return host
+	}
+	return "simple-canary"
+}
+
+type pagerDutyEvent struct {
+	RoutingKey string            `json:"routing_key"`
+	Action     string            `json:"event_action"`
+	DedupKey   string            `json:"dedup_key"`
+	Payload    *pagerDutyPayload `json:"payload,omitempty"`
+	Links      []pagerDutyLink   `json:"links,omitempty"`
+}
+
+type pagerDutyPayload struct {
+	Summary   string            `json:"summary"`
+	Source    string            `json:"source"`
+	Severity  string            `json:"severity"`
+	Timestamp time.Time         `json:"timestamp"`
+	Component string            `json:"component"`
+	Details   map[string]string `json:"custom_details"`
+}
+
+type pagerDutyLink struct {
+	Href string `json:"href"`
+	Text string `json:"text"`
+}
@@ -0,0 +1,102 @@
+package alert
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+
+	"github.com/iheanyi/simple-canary/internal/db"
+)
+
+// A Route picks the notifiers of the alerts about the tests it matches. The
+// alerts go down the first of its routes that matches, and on to the next
+// ones while they have Continue set. When none of its routes match, they go to
+// the notifiers of the route itself, which default to those of its parent,
+// or to all the notifiers for the root route.
+type Route struct {
+	db.TestMatcher
+	Notifiers []string `json:"notifiers"`
+	Continue  bool     `json:"continue"`
+	Routes    []*Route `json:"routes"`
+}
+
+// ParseRoute reads the JSON of the root route, which matches every test.
+func ParseRoute(data []byte) (*Route, error) {
+	root := new(Route)
+	if err := json.Unmarshal(data, root); err != nil {
+		return nil, fmt.Errorf("can't parse route: %v", err)
+	}
+	if root.Tests != "" || len(root.Labels) > 0 || root.Continue {
+		return nil, fmt.Errorf("the root route matches every test, it can't have tests, labels or continue")
+	}
+	if err := root.checkTests(); err != nil {
+		return nil, err
+	}
+	return root, nil
+}
+
+func (r *Route) checkTests() error {
+	if _, err := path.Match(r.Tests, ""); err != nil {
+		return fmt.Errorf("route has invalid tests %q: %v", r.Tests, err)
+	}
+	for _, child := range r.Routes {
+		if err := child.checkTests(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Check verifies that the route and its children only send to the given
+// notifiers.
+func (r *Route) Check(notifiers []Notifier) error {
+	known := make(map[string]bool, len(notifiers))
+	for _, n := range notifiers {
+		known[n.Name()] = true
+	}
+	var check func(r *Route) error
+	check = func(r *Route) error {
+		for _, name := range r.Notifiers {
+			if !known[name] {
+				return fmt.Errorf("route sends to notifier %q, which isn't defined", name)
+			}
+		}
+		for _, child := range r.Routes {
+			if err := check(child); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return check(r)
+}
+
+// route returns the names of the notifiers of the alerts about the test, and
+// whether the route matches it. The route inherits the given notifiers if it
+// has none.
+func (r *Route) route(name string, labels map[string]string, inherited []string) ([]string, bool) {
+	if !r.Matches(name, labels) {
+		return nil, false
+	}
+	own := r.Notifiers
+	if len(own) == 0 {
+		own = inherited
+	}
+	var notifiers []string
+	matched := false
+	for _, child := range r.Routes {
+		names, ok := child.route(name, labels, own)
+		if !ok {
+			continue
+		}
+		notifiers = append(notifiers, names...)
+		matched = true
+		if !child.Continue {
+			break
+		}
+	}
+	if !matched {
+		notifiers = own
+	}
+	return notifiers, true
+}
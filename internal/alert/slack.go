@@ -0,0 +1,94 @@
+package alert
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+// The default templates of Slack messages, in Slack's mrkdwn.
+var slackTemplates = map[string]string{
+	"title": `{{if eq .Status "firing"}}🔴 {{.TestName}} is failing{{else}}✅ {{.TestName}} recovered{{end}}`,
+	"text": `{{if eq .Status "firing"}}{{if .FailedStep}}Step *{{.FailedStep}}* failed: {{end}}` +
+		"`{{excerpt 200 .FailCause}}`" + `{{else}}The last run passed.{{end}}`,
+}
+
+// Slack posts alerts to a Slack incoming webhook, as blocks.
+type Slack struct {
+	name   string
+	url    string
+	tmpls  *chatTemplates
+	client *http.Client
+}
+
+func parseSlack(name string, data []byte) (*Slack, error) {
+	cfg, tmpls, timeout, err := parseChatConfig("slack", name, data, slackTemplates)
+	if err != nil {
+		return nil, err
+	}
+	return &Slack{
+		name:   name,
+		url:    cfg.URL,
+		tmpls:  tmpls,
+		client: &http.Client{Timeout: timeout},
+	}, nil
+}
+
+// Name implements Notifier.
+func (s *Slack) Name() string { return s.name }
+
+// Notify implements Notifier.
+func (s *Slack) Notify(ctx context.Context, alert *Alert) error {
+	title, text, err := s.tmpls.render(alert)
+	if err != nil {
+		return err
+	}
+	fields := []slackText{
+		{Type: "mrkdwn", Text: "*Test*\n" + alert.TestName},
+		{Type: "mrkdwn", Text: "*Duration*\n" + formatDuration(alert.Duration())},
+	}
+	if alert.FailedStep != "" {
+		fields = append(fields, slackText{Type: "mrkdwn", Text: "*Failing step*\n" + alert.FailedStep})
+	}
+	msg := slackMessage{
+		Text: title,
+		Blocks: []slackBlock{
+			{Type: "header", Text: &slackText{Type: "plain_text", Text: title}},
+			{Type: "section", Text: &slackText{Type: "mrkdwn", Text: text}, Fields: fields},
+			{Type: "actions", Elements: []slackButton{{
+				Type: "button",
+				Text: slackText{Type: "plain_text", Text: "View run"},
+				URL:  alert.URL,
+			}}},
+		},
+	}
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return Permanent(err)
+	}
+	return postJSON(ctx, s.client, s.url, nil, body)
+}
+
+type slackMessage struct {
+	// Text is shown in notifications, where blocks aren't.
+	Text   string       `json:"text"`
+	Blocks []slackBlock `json:"blocks"`
+}
+
+type slackBlock struct {
+	Type     string        `json:"type"`
+	Text     *slackText    `json:"text,omitempty"`
+	Fields   []slackText   `json:"fields,omitempty"`
+	Elements []slackButton `json:"elements,omitempty"`
+}
+
+type slackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type slackButton struct {
+	Type string    `json:"type"`
+	Text slackText `json:"text"`
+	URL  string    `json:"url"`
+}
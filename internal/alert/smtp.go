@@ -0,0 +1,360 @@
+package alert
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	htmltemplate "html/template"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/pborman/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// The default templates of emails. Those starting with digest_ are for the
+// daily digests, the others for single alerts.
+var emailTemplates = map[string]string{
+	"subject": `[{{.Status}}] {{.Canary}}: {{.TestName}} {{if eq .Status "firing"}}is failing{{else}}recovered{{end}}`,
+	"text": `{{.TestName}} {{if eq .Status "firing"}}is failing{{else}}recovered{{end}}.
+
+Run:      {{.URL}}
+Started:  {{.StartAt.Format "2006-01-02 15:04:05 MST"}}
+Duration: {{duration .Duration}}
+{{if .FailedStep}}Step:     {{.FailedStep}}
+{{end}}{{if .FailCause}}
+{{.FailCause}}{{end}}`,
+	"html": `<p><b>{{.TestName}}</b> {{if eq .Status "firing"}}is failing{{else}}recovered{{end}}.</p>
+<table>
+<tr><td>Run</td><td><a href="{{.URL}}">{{.RunID}}</a></td></tr>
+<tr><td>Started</td><td>{{.StartAt.Format "2006-01-02 15:04:05 MST"}}</td></tr>
+<tr><td>Duration</td><td>{{duration .Duration}}</td></tr>
+{{if .FailedStep}}<tr><td>Step</td><td>{{.FailedStep}}</td></tr>{{end}}
+</table>
+{{if .FailCause}}<pre>{{.FailCause}}</pre>{{end}}`,
+	"digest_subject": `{{.Canary}}: {{.Failures}} failures in {{len .Tests}} tests since {{.From.Format "Jan 2 15:04"}}`,
+	"digest_text": `Runs of the tests of {{.Canary}} from {{.From.Format "2006-01-02 15:04 MST"}} to {{.To.Format "2006-01-02 15:04 MST"}}:
+{{range .Tests}}
+{{.Name}}: {{.Failures}} of {{.Runs}} runs failed, {{.Fired}} alerts{{if .LastFailCause}}
+  last failure: {{excerpt 200 .LastFailCause}}{{end}}{{end}}`,
+	"digest_html": `<p>Runs of the tests of {{.Canary}} from {{.From.Format "2006-01-02 15:04 MST"}} to {{.To.Format "2006-01-02 15:04 MST"}}:</p>
+<table>
+<tr><th>Test</th><th>Runs</th><th>Failures</th><th>Alerts</th><th>Last failure</th></tr>
+{{range .Tests}}<tr><td>{{.Name}}</td><td>{{.Runs}}</td><td>{{.Failures}}</td><td>{{.Fired}}</td><td>{{excerpt 200 .LastFailCause}}</td></tr>
+{{end}}</table>`,
+}
+
+// The STARTTLS policies of the SMTP notifier.
+const (
+	startTLSAlways      = "always"
+	startTLSIfAvailable = "if_available"
+	startTLSNever       = "never"
+)
+
+type smtpConfig struct {
+	Host     string `json:"host"`
+	Port     int    `json:"port"`
+	StartTLS string `json:"starttls"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+	From     string `json:"from"`
+	// To are the recipients of the alerts about the tests that aren't in
+	// Recipients.
+	To         []string            `json:"to"`
+	Recipients map[string][]string `json:"recipients"`
+	Templates  map[string]string   `json:"templates"`
+	Timeout    string              `json:"timeout"`
+	Digest     *struct {
+		At       string `json:"at"`
+		Timezone string `json:"timezone"`
+	} `json:"digest"`
+}
+
+// SMTP emails alerts, or a daily digest of the runs when in digest mode.
+type SMTP struct {
+	name    string
+	addr    string
+	host    string
+	auth    smtp.Auth
+	tls     string
+	from    string
+	to      []string
+	byTest  map[string][]string
+	timeout time.Duration
+
+	subject, text *template.Template
+	html          *htmltemplate.Template
+	digestSubject *template.Template
+	digestText    *template.Template
+	digestHTML    *htmltemplate.Template
+	digest        *digest
+	digestAt      time.Duration
+	digestLoc     *time.Location
+}
+
+func parseSMTP(name string, data []byte) (*SMTP, error) {
+	cfg := &smtpConfig{Port: 587, StartTLS: startTLSAlways}
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("can't parse smtp notifier %q: %v", name, err)
+	}
+	switch {
+	case cfg.Host == "":
+		return nil, fmt.Errorf("smtp notifier %q needs a host", name)
+	case cfg.From == "":
+		return nil, fmt.Errorf("smtp notifier %q needs a from address", name)
+	case len(cfg.To) == 0 && len(cfg.Recipients) == 0:
+		return nil, fmt.Errorf("smtp notifier %q needs recipients", name)
+	}
+	switch cfg.StartTLS {
+	case startTLSAlways, startTLSIfAvailable, startTLSNever:
+	default:
+		return nil, fmt.Errorf("smtp notifier %q has unknown starttls %q, should be one of: always, if_available, never", name, cfg.StartTLS)
+	}
+	n := &SMTP{
+		name:    name,
+		addr:    net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port)),
+		host:    cfg.Host,
+		tls:     cfg.StartTLS,
+		from:    cfg.From,
+		to:      cfg.To,
+		byTest:  cfg.Recipients,
+		timeout: 30 * time.Second,
+	}
+	if cfg.Username != "" {
+		if cfg.StartTLS == startTLSNever && !isLocalhost(cfg.Host) {
+			return nil, fmt.Errorf("smtp notifier %q can't send its password to %s without starttls", name, cfg.Host)
+		}
+		n.auth = smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.Host)
+	}
+	if cfg.Timeout != "" {
+		var err error
+		if n.timeout, err = time.ParseDuration(cfg.Timeout); err != nil {
+			return nil, fmt.Errorf("smtp notifier %q has an invalid timeout: %v", name, err)
+		}
+	}
+	if err := n.parseTemplates(cfg.Templates); err != nil {
+		return nil, err
+	}
+	if cfg.Digest != nil {
+		if err := n.parseDigest(cfg.Digest.At, cfg.Digest.Timezone); err != nil {
+			return nil, err
+		}
+	}
+	return n, nil
+}
+
+func (n *SMTP) parseTemplates(overrides map[string]string) error {
+	for key := range overrides {
+		if _, ok := emailTemplates[key]; !ok {
+			return fmt.Errorf("notifier %q has no template named %q", n.name, key)
+		}
+	}
+	src := func(key string) string {
+		if s, ok := overrides[key]; ok {
+			return s
+		}
+		return emailTemplates[key]
+	}
+	text := func(key string) (t *template.Template, err error) {
+		t, err = template.New(key).Funcs(templateFuncs).Parse(src(key))
+		if err != nil {
+			err = fmt.Errorf("can't parse template %q of notifier %q: %v", key, n.name, err)
+		}
+		return t, err
+	}
+	html := func(key string) (t *htmltemplate.Template, err error) {
+		t, err = htmltemplate.New(key).Funcs(htmltemplate.FuncMap(templateFuncs)).Parse(src(key))
+		if err != nil {
+			err = fmt.Errorf("can't parse template %q of notifier %q: %v", key, n.name, err)
+		}
+		return t, err
+	}
+	var err error
+	if n.subject, err = text("subject"); err != nil {
+		return err
+	}
+	if n.text, err = text("text"); err != nil {
+		return err
+	}
+	if n.html, err = html("html"); err != nil {
+		return err
+	}
+	if n.digestSubject, err = text("digest_subject"); err != nil {
+		return err
+	}
+	if n.digestText, err = text("digest_text"); err != nil {
+		return err
+	}
+	n.digestHTML, err = html("digest_html")
+	return err
+}
+
+// Name implements Notifier.
+func (n *SMTP) Name() string { return n.name }
+
+// Notify implements Notifier. In digest mode, the manager leaves the alerts to
+// the next digest instead.
+func (n *SMTP) Notify(ctx context.Context, alert *Alert) error {
+	var subject, text, html bytes.Buffer
+	if err := n.subject.Execute(&subject, alert); err != nil {
+		return Permanent(fmt.Errorf("can't render subject: %v", err))
+	}
+	if err := n.text.Execute(&text, alert); err != nil {
+		return Permanent(fmt.Errorf("can't render text: %v", err))
+	}
+	if err := n.html.Execute(&html, alert); err != nil {
+		return Permanent(fmt.Errorf("can't render html: %v", err))
+	}
+	return n.send(ctx, n.recipients(alert.TestName), subject.String(), text.Bytes(), html.Bytes())
+}
+
+// recipients returns the addresses to which the alerts about the test are
+// sent.
+func (n *SMTP) recipients(test string) []string {
+	if to, ok := n.byTest[test]; ok {
+		return to
+	}
+	return n.to
+}
+
+// send emails a message with text and HTML alternatives.
+func (n *SMTP) send(ctx context.Context, to []string, subject string, text, html []byte) error {
+	if len(to) == 0 {
+		return nil
+	}
+	msg, err := n.message(to, subject, text, html)
+	if err != nil {
+		return Permanent(err)
+	}
+
+	deadline := time.Now().Add(n.timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	conn, err := (&net.Dialer{Deadline: deadline}).DialContext(ctx, "tcp", n.addr)
+	if err != nil {
+		return err
+	}
+	conn.SetDeadline(deadline)
+	c, err := smtp.NewClient(conn, n.host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer c.Close()
+
+	if n.tls != startTLSNever {
+		if ok, _ := c.Extension("STARTTLS"); ok {
+			if err := c.StartTLS(&tls.Config{ServerName: n.host}); err != nil {
+				return err
+			}
+		} else if n.tls == startTLSAlways {
+			return Permanent(fmt.Errorf("%s doesn't support STARTTLS", n.addr))
+		}
+	}
+	if n.auth != nil {
+		// Like net/smtp, only send the password in the clear to localhost.
+		if _, ok := c.TLSConnectionState(); !ok && !isLocalhost(n.host) {
+			return Permanent(fmt.Errorf("%s doesn't support STARTTLS, can't send the password in the clear", n.addr))
+		}
+		if err := c.Auth(n.auth); err != nil {
+			return smtpError(ctx, err)
+		}
+	}
+	if err := c.Mail(n.from); err != nil {
+		return smtpError(ctx, err)
+	}
+	for _, addr := range to {
+		if err := c.Rcpt(addr); err != nil {
+			return smtpError(ctx, err)
+		}
+	}
+	w, err := c.Data()
+	if err != nil {
+		return smtpError(ctx, err)
+	}
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return smtpError(ctx, err)
+	}
+	// The message was accepted, whatever comes of quitting.
+	setStatusCode(ctx, 250)
+	if err := c.Quit(); err != nil {
+		logrus.WithFields(logrus.Fields{"component": "alert", "notifier": n.name}).
+			WithError(err).Warn("can't quit SMTP session after the message was accepted")
+	}
+	return nil
+}
+
+func isLocalhost(host string) bool {
+	return host == "localhost" || host == "127.0.0.1" || host == "::1"
+}
+
+// smtpError records the reply code of the errors, and marks those with a 5xx
+// code as permanent.
+func smtpError(ctx context.Context, err error) error {
+	tpErr, ok := err.(*textproto.Error)
+	if !ok {
+		return err
+	}
+	setStatusCode(ctx, tpErr.Code)
+	if tpErr.Code/100 == 5 {
+		return Permanent(err)
+	}
+	return err
+}
+
+func (n *SMTP) message(to []string, subject string, text, html []byte) ([]byte, error) {
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	for _, part := range []struct {
+		contentType string
+		content     []byte
+	}{
+		{"text/plain; charset=utf-8", text},
+		{"text/html; charset=utf-8", html},
+	} {
+		pw, err := mw.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		qp := quotedprintable.NewWriter(pw)
+		if _, err := qp.Write(part.content); err != nil {
+			return nil, err
+		}
+		if err := qp.Close(); err != nil {
+			return nil, err
+		}
+	}
+	if err := mw.Close(); err != nil {
+		return nil, err
+	}
+
+	var msg bytes.Buffer
+	header := func(k, v string) { fmt.Fprintf(&msg, "%s: %s\r\n", k, v) }
+	header("From", n.from)
+	header("To", strings.Join(to, ", "))
+	header("Subject", mime.QEncoding.Encode("utf-8", strings.TrimSpace(subject)))
+	header("Date", time.Now().Format(time.RFC1123Z))
+	header("Message-ID", "<"+uuid.New()+"@"+n.host+">")
+	header("MIME-Version", "1.0")
+	header("Content-Type", `multipart/alternative; boundary="`+mw.Boundary()+`"`)
+	msg.WriteString("\r\n")
+	msg.Write(body.Bytes())
+	return msg.Bytes(), nil
+}
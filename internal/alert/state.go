@@ -0,0 +1,71 @@
+package alert
+
+import (
+	"time"
+
+	"github.com/iheanyi/simple-canary/internal/db"
+	"github.com/iheanyi/simple-canary/internal/js"
+)
+
+// observe accounts for the run in the state, per the rule, and reports whether
+// the alert started or stopped firing.
+func observe(state *db.AlertState, rule js.AlertRule, run *db.TestInstance) bool {
+	now := run.EndAt
+	state.LastRunID = run.TestID
+	if run.Pass {
+		state.ConsecutivePasses++
+		state.ConsecutiveFailures = 0
+	} else {
+		state.ConsecutiveFailures++
+		state.ConsecutivePasses = 0
+		if rule.Failures > 0 {
+			state.Failures = append(state.Failures, now)
+		}
+	}
+	state.Failures = within(state.Failures, now, rule.Window)
+
+	changed := false
+	switch {
+	case !state.Firing && shouldFire(state, rule):
+		state.Firing = true
+		changed = true
+	case state.Firing && state.ConsecutivePasses >= rule.ConsecutivePasses:
+		state.Firing = false
+		// Failures from before the recovery don't count towards firing
+		// again.
+		state.Failures = nil
+		changed = true
+	}
+	if changed {
+		state.Since = now
+		if rule.FlapChanges > 0 {
+			state.Changes = append(state.Changes, now)
+		}
+	}
+	state.Changes = within(state.Changes, now, rule.FlapWindow)
+	state.Flapping = rule.FlapChanges > 0 && len(state.Changes) >= rule.FlapChanges
+	return changed
+}
+
+func shouldFire(state *db.AlertState, rule js.AlertRule) bool {
+	if rule.ConsecutiveFailures > 0 && state.ConsecutiveFailures >= rule.ConsecutiveFailures {
+		return true
+	}
+	return rule.Failures > 0 && len(state.Failures) >= rule.Failures
+}
+
+// within returns the times that are less than window before now, or none if
+// the window is zero.
+func within(times []time.Time, now time.Time, window time.Duration) []time.Time {
+	if window <= 0 {
+		return nil
+	}
+	i := 0
+	for i < len(times) && now.Sub(times[i]) >= window {
+		i++
+	}
+	if i == len(times) {
+		return nil
+	}
+	return times[i:]
+}
@@ -0,0 +1,106 @@
+package alert
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+// The default templates of Teams messages, in Markdown.
+var teamsTemplates = map[string]string{
+	"title": `{{if eq .Status "firing"}}{{.TestName}} is failing{{else}}{{.TestName}} recovered{{end}}`,
+	"text": `{{if eq .Status "firing"}}{{if .FailedStep}}Step **{{.FailedStep}}** failed: {{end}}` +
+		"`{{excerpt 200 .FailCause}}`" + `{{else}}The last run passed.{{end}}`,
+}
+
+// Teams posts alerts to a Microsoft Teams incoming webhook, as message cards.
+type Teams struct {
+	name   string
+	url    string
+	tmpls  *chatTemplates
+	client *http.Client
+}
+
+func parseTeams(name string, data []byte) (*Teams, error) {
+	cfg, tmpls, timeout, err := parseChatConfig("teams", name, data, teamsTemplates)
+	if err != nil {
+		return nil, err
+	}
+	return &Teams{
+		name:   name,
+		url:    cfg.URL,
+		tmpls:  tmpls,
+		client: &http.Client{Timeout: timeout},
+	}, nil
+}
+
+// Name implements Notifier.
+func (t *Teams) Name() string { return t.name }
+
+// Notify implements Notifier.
+func (t *Teams) Notify(ctx context.Context, alert *Alert) error {
+	title, text, err := t.tmpls.render(alert)
+	if err != nil {
+		return err
+	}
+	color := "2EB886"
+	if alert.Status == Firing {
+		color = "D50000"
+	}
+	facts := []teamsFact{
+		{Name: "Test", Value: alert.TestName},
+		{Name: "Duration", Value: formatDuration(alert.Duration())},
+	}
+	if alert.FailedStep != "" {
+		facts = append(facts, teamsFact{Name: "Failing step", Value: alert.FailedStep})
+	}
+	card := teamsCard{
+		Type:       "MessageCard",
+		Context:    "https://schema.org/extensions",
+		Summary:    title,
+		ThemeColor: color,
+		Title:      title,
+		Sections:   []teamsSection{{Text: text, Facts: facts}},
+		Actions: []teamsAction{{
+			Type:    "OpenUri",
+			Name:    "View run",
+			Targets: []teamsTarget{{OS: "default", URI: alert.URL}},
+		}},
+	}
+	body, err := json.Marshal(card)
+	if err != nil {
+		return Permanent(err)
+	}
+	return postJSON(ctx, t.client, t.url, nil, body)
+}
+
+type teamsCard struct {
+	Type       string         `json:"@type"`
+	Context    string         `json:"@context"`
+	Summary    string         `json:"summary"`
+	ThemeColor string         `json:"themeColor"`
+	Title      string         `json:"title"`
+	Sections   []teamsSection `json:"sections"`
+	Actions    []teamsAction  `json:"potentialAction"`
+}
+
+type teamsSection struct {
+	Text  string      `json:"text"`
+	Facts []teamsFact `json:"facts"`
+}
+
+type teamsFact struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type teamsAction struct {
+	Type    string        `json:"@type"`
+	Name    string        `json:"name"`
+	Targets []teamsTarget `json:"targets"`
+}
+
+type teamsTarget struct {
+	OS  string `json:"os"`
+	URI string `json:"uri"`
+}
@@ -0,0 +1,124 @@
+package alert
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Webhook POSTs alerts as JSON to a URL. When it has a secret, the body is
+// signed with HMAC-SHA256, the signature being sent as
+// "X-Canary-Signature: sha256=<hex>".
+type Webhook struct {
+	name    string
+	url     string
+	secret  []byte
+	headers map[string]string
+	client  *http.Client
+}
+
+type webhookConfig struct {
+	URL     string            `json:"url"`
+	Secret  string            `json:"secret"`
+	Headers map[string]string `json:"headers"`
+	Timeout string            `json:"timeout"`
+}
+
+// NewWebhook creates a webhook posting to the URL, signing with the secret
+// unless it's empty.
+func NewWebhook(name, url, secret string, headers map[string]string, timeout time.Duration) *Webhook {
+	return &Webhook{
+		name:    name,
+		url:     url,
+		secret:  []byte(secret),
+		headers: headers,
+		client:  &http.Client{Timeout: timeout},
+	}
+}
+
+func parseWebhook(name string, data []byte) (*Webhook, error) {
+	cfg := new(webhookConfig)
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("can't parse webhook %q: %v", name, err)
+	}
+	if u, err := url.Parse(cfg.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return nil, fmt.Errorf("webhook %q needs an http or https url, not %q", name, cfg.URL)
+	}
+	timeout := 10 * time.Second
+	if cfg.Timeout != "" {
+		var err error
+		if timeout, err = time.ParseDuration(cfg.Timeout); err != nil {
+			return nil, fmt.Errorf("webhook %q has an invalid timeout: %v", name, err)
+		}
+	}
+	return NewWebhook(name, cfg.URL, cfg.Secret, cfg.Headers, timeout), nil
+}
+
+// Name implements Notifier.
+func (wh *Webhook) Name() string { return wh.name }
+
+// Notify implements Notifier.
+func (wh *Webhook) Notify(ctx context.Context, alert *Alert) error {
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return Permanent(err)
+	}
+	headers := make(map[string]string, len(wh.headers)+2)
+	for k, v := range wh.headers {
+		headers[k] = v
+	}
+	if delivery, ok := deliveryID(ctx); ok {
+		headers["X-Canary-Delivery"] = delivery
+	}
+	if len(wh.secret) > 0 {
+		headers["X-Canary-Signature"] = "sha256=" + Sign(wh.secret, body)
+	}
+	return postJSON(ctx, wh.client, wh.url, headers, body)
+}
+
+// Sign returns the hex HMAC-SHA256 of the body, with the secret as key.
+func Sign(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// postJSON POSTs the payload. Responses other than 2xx are errors, permanent
+// ones for 4xx other than 408 and 429.
+func postJSON(ctx context.Context, client *http.Client, url string, headers map[string]string, body []byte) error {
+	req, err := http.NewRequest("POST", url, bytes.NewReader(body))
+	if err != nil {
+		return Permanent(err)
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "simple-canary")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	setStatusCode(ctx, resp.StatusCode)
+	msg, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 512))
+	if resp.StatusCode/100 == 2 {
+		return nil
+	}
+	err = fmt.Errorf("responded %s: %s", resp.Status, bytes.TrimSpace(msg))
+	if resp.StatusCode/100 == 4 && resp.StatusCode != http.StatusRequestTimeout && resp.StatusCode != http.StatusTooManyRequests {
+		return Permanent(err)
+	}
+	return err
+}
@@ -0,0 +1,20 @@
+package app
+
+import (
+	"net/http"
+)
+
+// backup streams a consistent snapshot of the database.
+func (app *App) backup(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Disposition", `attachment; filename="canary.db"`)
+	n, err := app.db.Backup(w)
+	if err != nil {
+		app.l.WithError(err).Error("can't backup database")
+		if n == 0 {
+			http.Error(w, "can't backup database", http.StatusInternalServerError)
+		}
+		return
+	}
+	app.l.WithField("bytes", n).Info("database backed up")
+}
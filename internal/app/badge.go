@@ -0,0 +1,183 @@
+package app
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"fmt"
+	"html/template"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	dbpkg "github.com/iheanyi/simple-canary/internal/db"
+)
+
+// badgeMaxAge is how long badges can be cached for.
+const badgeMaxAge = time.Minute
+
+var badgeColors = map[string]string{
+	"passing": "#4c1",
+	"failing": "#e05d44",
+	"paused":  "#9f9f9f",
+	"unknown": "#9f9f9f",
+}
+
+// uptimeWindows are the windows over which the uptime can be shown on badges.
+var uptimeWindows = map[string]time.Duration{
+	"24h": 24 * time.Hour,
+	"7d":  7 * 24 * time.Hour,
+	"30d": 30 * 24 * time.Hour,
+}
+
+// testBadge renders a badge telling how a test is doing. With an uptime
+// parameter of 24h, 7d or 30d, it shows the uptime over that window instead
+// of the state of the test.
+func (app *App) testBadge(w http.ResponseWriter, r *http.Request) {
+	name := mux.Vars(r)["test"]
+	if _, ok := app.res.tests[name]; !ok {
+		http.NotFound(w, r)
+		return
+	}
+	app.badge(w, r, name, []string{name})
+}
+
+// canaryBadge renders a badge telling how the tests of the canary are doing
+// as a whole: failing if any of them is.
+func (app *App) canaryBadge(w http.ResponseWriter, r *http.Request) {
+	names := make([]string, 0, len(app.res.configs))
+	for _, cfg := range app.res.configs {
+		names = append(names, cfg.Name)
+	}
+	app.badge(w, r, app.res.canary.Name, names)
+}
+
+func (app *App) badge(w http.ResponseWriter, r *http.Request, label string, names []string) {
+	var window time.Duration
+	if v := r.URL.Query().Get("uptime"); v != "" {
+		var ok bool
+		if window, ok = uptimeWindows[v]; !ok {
+			http.Error(w, "uptime must be one of 24h, 7d or 30d", http.StatusBadRequest)
+			return
+		}
+	}
+	if v := r.URL.Query().Get("label"); v != "" {
+		label = v
+	}
+
+	state, message, err := app.badgeState(names, window)
+	if err != nil {
+		app.l.WithError(err).Error("can't compute badge")
+		http.Error(w, "can't compute badge", http.StatusInternalServerError)
+		return
+	}
+
+	labelWidth, messageWidth := badgeTextWidth(label), badgeTextWidth(message)
+	var buf bytes.Buffer
+	err = badgeTmpl.Execute(&buf, struct {
+		Label, Message, Color           string
+		Width, LabelWidth, MessageWidth int
+		LabelX, MessageX                int
+	}{
+		Label:        label,
+		Message:      message,
+		Color:        badgeColors[state],
+		Width:        labelWidth + messageWidth,
+		LabelWidth:   labelWidth,
+		MessageWidth: messageWidth,
+		LabelX:       labelWidth / 2,
+		MessageX:     labelWidth + messageWidth/2,
+	})
+	if err != nil {
+		app.l.WithError(err).Error("can't render badge")
+		http.Error(w, "can't render badge", http.StatusInternalServerError)
+		return
+	}
+
+	etag := fmt.Sprintf(`"%x"`, sha1.Sum(buf.Bytes()))
+	w.Header().Set("Content-Type", "image/svg+xml")
+	w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(badgeMaxAge.Seconds())))
+	w.Header().Set("ETag", etag)
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Write(buf.Bytes())
+}
+
+// badgeState tells whether the tests are passing, failing, paused or of
+// unknown state, along with the message to show for it. Tests that are paused
+// or have never run don't count unless they all are.
+func (app *App) badgeState(names []string, window time.Duration) (string, string, error) {
+	var paused, passing, failing int
+	for _, name := range names {
+		pause, err := app.res.activePause(name)
+		if err != nil {
+			return "", "", err
+		}
+		if pause != nil {
+			paused++
+			continue
+		}
+		last, err := app.res.lastRun(dbpkg.RunFilter{Name: name})
+		switch {
+		case err != nil:
+			return "", "", err
+		case last == nil:
+		case last.Pass:
+			passing++
+		default:
+			failing++
+		}
+	}
+
+	state := "unknown"
+	switch {
+	case failing > 0:
+		state = "failing"
+	case passing > 0:
+		state = "passing"
+	case paused > 0:
+		state = "paused"
+	}
+	if window == 0 {
+		return state, state, nil
+	}
+
+	now := time.Now()
+	since := dbpkg.Hourly.Truncate(now.Add(-window))
+	var rollups []dbpkg.Rollup
+	for _, name := range names {
+		rs, err := app.db.ListRollups(name, dbpkg.Hourly, since, now.Add(time.Hour))
+		if err != nil {
+			return "", "", err
+		}
+		rollups = append(rollups, rs...)
+	}
+	pct := uptime(rollups, since)
+	if pct == nil {
+		return state, "no runs", nil
+	}
+	return state, fmt.Sprintf("%.2f%%", *pct), nil
+}
+
+// badgeTextWidth estimates the width in pixels of text in the font of the
+// badges.
+func badgeTextWidth(s string) int {
+	return 7*len([]rune(s)) + 10
+}
+
+var badgeTmpl = template.Must(template.New("badge").Parse(`<svg xmlns="http://www.w3.org/2000/svg" width="{{.Width}}" height="20" role="img" aria-label="{{.Label}}: {{.Message}}">
+<title>{{.Label}}: {{.Message}}</title>
+<linearGradient id="s" x2="0" y2="100%"><stop offset="0" stop-color="#bbb" stop-opacity=".1"/><stop offset="1" stop-opacity=".1"/></linearGradient>
+<clipPath id="r"><rect width="{{.Width}}" height="20" rx="3" fill="#fff"/></clipPath>
+<g clip-path="url(#r)">
+<rect width="{{.LabelWidth}}" height="20" fill="#555"/>
+<rect x="{{.LabelWidth}}" width="{{.MessageWidth}}" height="20" fill="{{.Color}}"/>
+<rect width="{{.Width}}" height="20" fill="url(#s)"/>
+</g>
+<g fill="#fff" text-anchor="middle" font-family="Verdana,Geneva,DejaVu Sans,sans-serif" font-size="11">
+<text x="{{.LabelX}}" y="14">{{.Label}}</text>
+<text x="{{.MessageX}}" y="14">{{.Message}}</text>
+</g>
+</svg>
+`))
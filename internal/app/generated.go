@@ -12,6 +12,8 @@ import (
 	graphql "github.com/99designs/gqlgen/graphql"
 	introspection "github.com/99designs/gqlgen/graphql/introspection"
 	db "github.com/iheanyi/simple-canary/internal/db"
+	js "github.com/iheanyi/simple-canary/internal/js"
+	slo "github.com/iheanyi/simple-canary/internal/slo"
 	gqlparser "github.com/vektah/gqlparser"
 	ast "github.com/vektah/gqlparser/ast"
 )
@@ -32,7 +34,19 @@ type Config struct {
 }
 
 type ResolverRoot interface {
+	Alert() AlertResolver
+	AlertTransition() AlertTransitionResolver
+	BurnRate() BurnRateResolver
+	DeliveryAttempt() DeliveryAttemptResolver
+	LogEntry() LogEntryResolver
+	Mutation() MutationResolver
+	Pause() PauseResolver
 	Query() QueryResolver
+	Rollup() RollupResolver
+	SLOReport() SLOReportResolver
+	Silence() SilenceResolver
+	Subscription() SubscriptionResolver
+	TestDefinition() TestDefinitionResolver
 	TestInstance() TestInstanceResolver
 }
 
@@ -40,10 +54,156 @@ type DirectiveRoot struct {
 }
 
 type ComplexityRoot struct {
+	Alert struct {
+		Name                func(childComplexity int) int
+		State               func(childComplexity int) int
+		Since               func(childComplexity int) int
+		Flapping            func(childComplexity int) int
+		ConsecutiveFailures func(childComplexity int) int
+		ConsecutivePasses   func(childComplexity int) int
+		LastRun             func(childComplexity int) int
+	}
+
+	AlertHistory struct {
+		Name        func(childComplexity int) int
+		From        func(childComplexity int) int
+		To          func(childComplexity int) int
+		Transitions func(childComplexity int) int
+		Deliveries  func(childComplexity int) int
+	}
+
+	AlertTransition struct {
+		At       func(childComplexity int) int
+		State    func(childComplexity int) int
+		Flapping func(childComplexity int) int
+		Run      func(childComplexity int) int
+	}
+
+	BurnRate struct {
+		Window       func(childComplexity int) int
+		Runs         func(childComplexity int) int
+		SuccessRatio func(childComplexity int) int
+		Rate         func(childComplexity int) int
+	}
+
+	DeliveryAttempt struct {
+		DeliveryId func(childComplexity int) int
+		Notifier   func(childComplexity int) int
+		Attempt    func(childComplexity int) int
+		At         func(childComplexity int) int
+		Latency    func(childComplexity int) int
+		StatusCode func(childComplexity int) int
+		Error      func(childComplexity int) int
+		Final      func(childComplexity int) int
+		SilencedBy func(childComplexity int) int
+		Run        func(childComplexity int) int
+	}
+
+	Label struct {
+		Name  func(childComplexity int) int
+		Value func(childComplexity int) int
+	}
+
+	LogEntry struct {
+		Time    func(childComplexity int) int
+		Level   func(childComplexity int) int
+		Message func(childComplexity int) int
+		Fields  func(childComplexity int) int
+	}
+
+	LogField struct {
+		Key   func(childComplexity int) int
+		Value func(childComplexity int) int
+	}
+
+	Mutation struct {
+		PauseTest     func(childComplexity int, name string, until *time.Time, reason string) int
+		ResumeTest    func(childComplexity int, name string) int
+		CreateSilence func(childComplexity int, tests *string, labels []LabelInput, start_at *time.Time, end_at time.Time, comment string) int
+		ExpireSilence func(childComplexity int, id string) int
+	}
+
+	PageInfo struct {
+		HasNextPage     func(childComplexity int) int
+		HasPreviousPage func(childComplexity int) int
+		StartCursor     func(childComplexity int) int
+		EndCursor       func(childComplexity int) int
+	}
+
+	Pause struct {
+		Until    func(childComplexity int) int
+		Reason   func(childComplexity int) int
+		PausedBy func(childComplexity int) int
+		PausedAt func(childComplexity int) int
+	}
+
 	Query struct {
-		Tests        func(childComplexity int) int
+		Runs         func(childComplexity int, name *string, pass *bool, fail_kind *FailureKind, from *time.Time, to *time.Time, first int, after *string) int
 		Test         func(childComplexity int, id string) int
 		OngoingTests func(childComplexity int) int
+		Definitions  func(childComplexity int) int
+		Definition   func(childComplexity int, name string) int
+		Stats        func(childComplexity int, name string, from time.Time, to time.Time, granularity Granularity) int
+		Slo          func(childComplexity int, name string) int
+		Alerts       func(childComplexity int) int
+		AlertHistory func(childComplexity int, name string, from *time.Time, to *time.Time) int
+		Silences     func(childComplexity int, active *bool) int
+	}
+
+	Rollup struct {
+		Name        func(childComplexity int) int
+		Granularity func(childComplexity int) int
+		StartAt     func(childComplexity int) int
+		Runs        func(childComplexity int) int
+		Passes      func(childComplexity int) int
+		Failures    func(childComplexity int) int
+		MinDuration func(childComplexity int) int
+		AvgDuration func(childComplexity int) int
+		MaxDuration func(childComplexity int) int
+		P95Duration func(childComplexity int) int
+	}
+
+	Sloreport struct {
+		Name                 func(childComplexity int) int
+		Target               func(childComplexity int) int
+		Window               func(childComplexity int) int
+		SuccessRatio         func(childComplexity int) int
+		ErrorBudgetRemaining func(childComplexity int) int
+		BurnRates            func(childComplexity int) int
+	}
+
+	Silence struct {
+		Id        func(childComplexity int) int
+		Tests     func(childComplexity int) int
+		Labels    func(childComplexity int) int
+		StartAt   func(childComplexity int) int
+		EndAt     func(childComplexity int) int
+		Comment   func(childComplexity int) int
+		CreatedBy func(childComplexity int) int
+		CreatedAt func(childComplexity int) int
+		Active    func(childComplexity int) int
+	}
+
+	Subscription struct {
+		RunStarted  func(childComplexity int) int
+		RunFinished func(childComplexity int, name *string) int
+		RunLog      func(childComplexity int, id string) int
+	}
+
+	TestDefinition struct {
+		Name        func(childComplexity int) int
+		Canary      func(childComplexity int) int
+		Frequency   func(childComplexity int) int
+		Timeout     func(childComplexity int) int
+		Source      func(childComplexity int) int
+		Labels      func(childComplexity int) int
+		Slo         func(childComplexity int) int
+		Status      func(childComplexity int) int
+		LastRun     func(childComplexity int) int
+		LastSuccess func(childComplexity int) int
+		NextRunAt   func(childComplexity int) int
+		Pause       func(childComplexity int) int
+		Runs        func(childComplexity int, pass *bool, fail_kind *FailureKind, from *time.Time, to *time.Time, first int, after *string) int
 	}
 
 	TestInstance struct {
@@ -53,13 +213,122 @@ type ComplexityRoot struct {
 		EndAt     func(childComplexity int) int
 		Pass      func(childComplexity int) int
 		FailCause func(childComplexity int) int
+		FailKind  func(childComplexity int) int
+	}
+
+	TestInstanceConnection struct {
+		Edges      func(childComplexity int) int
+		PageInfo   func(childComplexity int) int
+		TotalCount func(childComplexity int) int
+	}
+
+	TestInstanceEdge struct {
+		Cursor func(childComplexity int) int
+		Node   func(childComplexity int) int
 	}
 }
 
+type AlertResolver interface {
+	Name(ctx context.Context, obj *db.AlertState) (string, error)
+	State(ctx context.Context, obj *db.AlertState) (AlertState, error)
+	Since(ctx context.Context, obj *db.AlertState) (*time.Time, error)
+
+	ConsecutiveFailures(ctx context.Context, obj *db.AlertState) (int, error)
+	ConsecutivePasses(ctx context.Context, obj *db.AlertState) (int, error)
+	LastRun(ctx context.Context, obj *db.AlertState) (*db.TestInstance, error)
+}
+type AlertTransitionResolver interface {
+	State(ctx context.Context, obj *db.AlertTransition) (AlertState, error)
+
+	Run(ctx context.Context, obj *db.AlertTransition) (*db.TestInstance, error)
+}
+type BurnRateResolver interface {
+	Window(ctx context.Context, obj *slo.BurnRate) (string, error)
+
+	SuccessRatio(ctx context.Context, obj *slo.BurnRate) (float64, error)
+}
+type DeliveryAttemptResolver interface {
+	DeliveryID(ctx context.Context, obj *db.DeliveryAttempt) (string, error)
+
+	Latency(ctx context.Context, obj *db.DeliveryAttempt) (float64, error)
+	StatusCode(ctx context.Context, obj *db.DeliveryAttempt) (*int, error)
+	Error(ctx context.Context, obj *db.DeliveryAttempt) (*string, error)
+
+	SilencedBy(ctx context.Context, obj *db.DeliveryAttempt) (*string, error)
+	Run(ctx context.Context, obj *db.DeliveryAttempt) (*db.TestInstance, error)
+}
+type LogEntryResolver interface {
+	Fields(ctx context.Context, obj *db.LogEntry) ([]LogField, error)
+}
+type MutationResolver interface {
+	PauseTest(ctx context.Context, name string, until *time.Time, reason string) (js.TestConfig, error)
+	ResumeTest(ctx context.Context, name string) (js.TestConfig, error)
+	CreateSilence(ctx context.Context, tests *string, labels []LabelInput, start_at *time.Time, end_at time.Time, comment string) (db.Silence, error)
+	ExpireSilence(ctx context.Context, id string) (db.Silence, error)
+}
+type PauseResolver interface {
+	Until(ctx context.Context, obj *db.Pause) (*time.Time, error)
+
+	PausedBy(ctx context.Context, obj *db.Pause) (string, error)
+	PausedAt(ctx context.Context, obj *db.Pause) (time.Time, error)
+}
 type QueryResolver interface {
-	Tests(ctx context.Context) ([]db.TestInstance, error)
+	Runs(ctx context.Context, name *string, pass *bool, fail_kind *FailureKind, from *time.Time, to *time.Time, first int, after *string) (TestInstanceConnection, error)
 	Test(ctx context.Context, id string) (*db.TestInstance, error)
 	OngoingTests(ctx context.Context) ([]db.TestInstance, error)
+	Definitions(ctx context.Context) ([]js.TestConfig, error)
+	Definition(ctx context.Context, name string) (*js.TestConfig, error)
+	Stats(ctx context.Context, name string, from time.Time, to time.Time, granularity Granularity) ([]db.Rollup, error)
+	Slo(ctx context.Context, name string) (*slo.Report, error)
+	Alerts(ctx context.Context) ([]db.AlertState, error)
+	AlertHistory(ctx context.Context, name string, from *time.Time, to *time.Time) (AlertHistory, error)
+	Silences(ctx context.Context, active *bool) ([]db.Silence, error)
+}
+type RollupResolver interface {
+	Name(ctx context.Context, obj *db.Rollup) (string, error)
+	Granularity(ctx context.Context, obj *db.Rollup) (Granularity, error)
+	StartAt(ctx context.Context, obj *db.Rollup) (time.Time, error)
+
+	MinDuration(ctx context.Context, obj *db.Rollup) (float64, error)
+	AvgDuration(ctx context.Context, obj *db.Rollup) (float64, error)
+	MaxDuration(ctx context.Context, obj *db.Rollup) (float64, error)
+	P95Duration(ctx context.Context, obj *db.Rollup) (float64, error)
+}
+type SLOReportResolver interface {
+	Target(ctx context.Context, obj *slo.Report) (float64, error)
+	Window(ctx context.Context, obj *slo.Report) (string, error)
+	SuccessRatio(ctx context.Context, obj *slo.Report) (float64, error)
+	ErrorBudgetRemaining(ctx context.Context, obj *slo.Report) (float64, error)
+	BurnRates(ctx context.Context, obj *slo.Report) ([]slo.BurnRate, error)
+}
+type SilenceResolver interface {
+	Tests(ctx context.Context, obj *db.Silence) (*string, error)
+	Labels(ctx context.Context, obj *db.Silence) ([]Label, error)
+	StartAt(ctx context.Context, obj *db.Silence) (time.Time, error)
+	EndAt(ctx context.Context, obj *db.Silence) (time.Time, error)
+
+	CreatedBy(ctx context.Context, obj *db.Silence) (string, error)
+	CreatedAt(ctx context.Context, obj *db.Silence) (time.Time, error)
+	Active(ctx context.Context, obj *db.Silence) (bool, error)
+}
+type SubscriptionResolver interface {
+	RunStarted(ctx context.Context) (<-chan db.TestInstance, error)
+	RunFinished(ctx context.Context, name *string) (<-chan db.TestInstance, error)
+	RunLog(ctx context.Context, id string) (<-chan db.LogEntry, error)
+}
+type TestDefinitionResolver interface {
+	Canary(ctx context.Context, obj *js.TestConfig) (string, error)
+	Frequency(ctx context.Context, obj *js.TestConfig) (float64, error)
+	Timeout(ctx context.Context, obj *js.TestConfig) (float64, error)
+
+	Labels(ctx context.Context, obj *js.TestConfig) ([]Label, error)
+	Slo(ctx context.Context, obj *js.TestConfig) (*slo.Report, error)
+	Status(ctx context.Context, obj *js.TestConfig) (TestStatus, error)
+	LastRun(ctx context.Context, obj *js.TestConfig) (*db.TestInstance, error)
+	LastSuccess(ctx context.Context, obj *js.TestConfig) (*db.TestInstance, error)
+	NextRunAt(ctx context.Context, obj *js.TestConfig) (*time.Time, error)
+	Pause(ctx context.Context, obj *js.TestConfig) (*db.Pause, error)
+	Runs(ctx context.Context, obj *js.TestConfig, pass *bool, fail_kind *FailureKind, from *time.Time, to *time.Time, first int, after *string) (TestInstanceConnection, error)
 }
 type TestInstanceResolver interface {
 	ID(ctx context.Context, obj *db.TestInstance) (string, error)
@@ -68,24 +337,48 @@ type TestInstanceResolver interface {
 	EndAt(ctx context.Context, obj *db.TestInstance) (*time.Time, error)
 
 	FailCause(ctx context.Context, obj *db.TestInstance) (*string, error)
+	FailKind(ctx context.Context, obj *db.TestInstance) (*FailureKind, error)
 }
 
-func field_Query_test_args(rawArgs map[string]interface{}) (map[string]interface{}, error) {
+func field_Mutation_pauseTest_args(rawArgs map[string]interface{}) (map[string]interface{}, error) {
 	args := map[string]interface{}{}
 	var arg0 string
-	if tmp, ok := rawArgs["id"]; ok {
+	if tmp, ok := rawArgs["name"]; ok {
 		var err error
 		arg0, err = graphql.UnmarshalString(tmp)
 		if err != nil {
 			return nil, err
 		}
 	}
-	args["id"] = arg0
+	args["name"] = arg0
+	var arg1 *time.Time
+	if tmp, ok := rawArgs["until"]; ok {
+		var err error
+		var ptr1 time.Time
+		if tmp != nil {
+			ptr1, err = graphql.UnmarshalTime(tmp)
+			arg1 = &ptr1
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["until"] = arg1
+	var arg2 string
+	if tmp, ok := rawArgs["reason"]; ok {
+		var err error
+		arg2, err = graphql.UnmarshalString(tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["reason"] = arg2
 	return args, nil
 
 }
 
-func field_Query___type_args(rawArgs map[string]interface{}) (map[string]interface{}, error) {
+func field_Mutation_resumeTest_args(rawArgs map[string]interface{}) (map[string]interface{}, error) {
 	args := map[string]interface{}{}
 	var arg0 string
 	if tmp, ok := rawArgs["name"]; ok {
@@ -100,159 +393,5339 @@ func field_Query___type_args(rawArgs map[string]interface{}) (map[string]interfa
 
 }
 
-func field___Type_fields_args(rawArgs map[string]interface{}) (map[string]interface{}, error) {
+func field_Mutation_createSilence_args(rawArgs map[string]interface{}) (map[string]interface{}, error) {
 	args := map[string]interface{}{}
-	var arg0 bool
-	if tmp, ok := rawArgs["includeDeprecated"]; ok {
+	var arg0 *string
+	if tmp, ok := rawArgs["tests"]; ok {
 		var err error
-		arg0, err = graphql.UnmarshalBoolean(tmp)
+		var ptr1 string
+		if tmp != nil {
+			ptr1, err = graphql.UnmarshalString(tmp)
+			arg0 = &ptr1
+		}
+
 		if err != nil {
 			return nil, err
 		}
 	}
-	args["includeDeprecated"] = arg0
+	args["tests"] = arg0
+	var arg1 []LabelInput
+	if tmp, ok := rawArgs["labels"]; ok {
+		var err error
+		var rawIf1 []interface{}
+		if tmp != nil {
+			if tmp1, ok := tmp.([]interface{}); ok {
+				rawIf1 = tmp1
+			} else {
+				rawIf1 = []interface{}{tmp}
+			}
+		}
+		arg1 = make([]LabelInput, len(rawIf1))
+		for idx1 := range rawIf1 {
+			arg1[idx1], err = UnmarshalLabelInput(rawIf1[idx1])
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["labels"] = arg1
+	var arg2 *time.Time
+	if tmp, ok := rawArgs["start_at"]; ok {
+		var err error
+		var ptr1 time.Time
+		if tmp != nil {
+			ptr1, err = graphql.UnmarshalTime(tmp)
+			arg2 = &ptr1
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["start_at"] = arg2
+	var arg3 time.Time
+	if tmp, ok := rawArgs["end_at"]; ok {
+		var err error
+		arg3, err = graphql.UnmarshalTime(tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["end_at"] = arg3
+	var arg4 string
+	if tmp, ok := rawArgs["comment"]; ok {
+		var err error
+		arg4, err = graphql.UnmarshalString(tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["comment"] = arg4
 	return args, nil
 
 }
 
-func field___Type_enumValues_args(rawArgs map[string]interface{}) (map[string]interface{}, error) {
+func field_Mutation_expireSilence_args(rawArgs map[string]interface{}) (map[string]interface{}, error) {
 	args := map[string]interface{}{}
-	var arg0 bool
-	if tmp, ok := rawArgs["includeDeprecated"]; ok {
+	var arg0 string
+	if tmp, ok := rawArgs["id"]; ok {
 		var err error
-		arg0, err = graphql.UnmarshalBoolean(tmp)
+		arg0, err = graphql.UnmarshalID(tmp)
 		if err != nil {
 			return nil, err
 		}
 	}
-	args["includeDeprecated"] = arg0
+	args["id"] = arg0
 	return args, nil
 
 }
 
-type executableSchema struct {
-	resolvers  ResolverRoot
-	directives DirectiveRoot
-	complexity ComplexityRoot
-}
-
-func (e *executableSchema) Schema() *ast.Schema {
-	return parsedSchema
-}
-
-func (e *executableSchema) Complexity(typeName, field string, childComplexity int, rawArgs map[string]interface{}) (int, bool) {
-	switch typeName + "." + field {
-
-	case "Query.tests":
-		if e.complexity.Query.Tests == nil {
-			break
+func field_Query_runs_args(rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	args := map[string]interface{}{}
+	var arg0 *string
+	if tmp, ok := rawArgs["name"]; ok {
+		var err error
+		var ptr1 string
+		if tmp != nil {
+			ptr1, err = graphql.UnmarshalString(tmp)
+			arg0 = &ptr1
 		}
 
-		return e.complexity.Query.Tests(childComplexity), true
-
-	case "Query.test":
-		if e.complexity.Query.Test == nil {
-			break
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["name"] = arg0
+	var arg1 *bool
+	if tmp, ok := rawArgs["pass"]; ok {
+		var err error
+		var ptr1 bool
+		if tmp != nil {
+			ptr1, err = graphql.UnmarshalBoolean(tmp)
+			arg1 = &ptr1
 		}
 
-		args, err := field_Query_test_args(rawArgs)
 		if err != nil {
-			return 0, false
+			return nil, err
 		}
-
-		return e.complexity.Query.Test(childComplexity, args["id"].(string)), true
-
-	case "Query.ongoingTests":
-		if e.complexity.Query.OngoingTests == nil {
-			break
+	}
+	args["pass"] = arg1
+	var arg2 *FailureKind
+	if tmp, ok := rawArgs["fail_kind"]; ok {
+		var err error
+		var ptr1 FailureKind
+		if tmp != nil {
+			err = (&ptr1).UnmarshalGQL(tmp)
+			arg2 = &ptr1
 		}
 
-		return e.complexity.Query.OngoingTests(childComplexity), true
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["fail_kind"] = arg2
+	var arg3 *time.Time
+	if tmp, ok := rawArgs["from"]; ok {
+		var err error
+		var ptr1 time.Time
+		if tmp != nil {
+			ptr1, err = graphql.UnmarshalTime(tmp)
+			arg3 = &ptr1
+		}
 
-	case "TestInstance.id":
-		if e.complexity.TestInstance.Id == nil {
-			break
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["from"] = arg3
+	var arg4 *time.Time
+	if tmp, ok := rawArgs["to"]; ok {
+		var err error
+		var ptr1 time.Time
+		if tmp != nil {
+			ptr1, err = graphql.UnmarshalTime(tmp)
+			arg4 = &ptr1
 		}
 
-		return e.complexity.TestInstance.Id(childComplexity), true
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["to"] = arg4
+	var arg5 int
+	if tmp, ok := rawArgs["first"]; ok {
+		var err error
+		arg5, err = graphql.UnmarshalInt(tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["first"] = arg5
+	var arg6 *string
+	if tmp, ok := rawArgs["after"]; ok {
+		var err error
+		var ptr1 string
+		if tmp != nil {
+			ptr1, err = graphql.UnmarshalString(tmp)
+			arg6 = &ptr1
+		}
 
-	case "TestInstance.name":
-		if e.complexity.TestInstance.Name == nil {
-			break
+		if err != nil {
+			return nil, err
 		}
+	}
+	args["after"] = arg6
+	return args, nil
 
-		return e.complexity.TestInstance.Name(childComplexity), true
+}
 
-	case "TestInstance.start_at":
-		if e.complexity.TestInstance.StartAt == nil {
-			break
+func field_Query_test_args(rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	args := map[string]interface{}{}
+	var arg0 string
+	if tmp, ok := rawArgs["id"]; ok {
+		var err error
+		arg0, err = graphql.UnmarshalString(tmp)
+		if err != nil {
+			return nil, err
 		}
+	}
+	args["id"] = arg0
+	return args, nil
 
-		return e.complexity.TestInstance.StartAt(childComplexity), true
+}
 
-	case "TestInstance.end_at":
-		if e.complexity.TestInstance.EndAt == nil {
-			break
+func field_Query_definition_args(rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	args := map[string]interface{}{}
+	var arg0 string
+	if tmp, ok := rawArgs["name"]; ok {
+		var err error
+		arg0, err = graphql.UnmarshalString(tmp)
+		if err != nil {
+			return nil, err
 		}
+	}
+	args["name"] = arg0
+	return args, nil
 
-		return e.complexity.TestInstance.EndAt(childComplexity), true
+}
 
-	case "TestInstance.pass":
-		if e.complexity.TestInstance.Pass == nil {
-			break
+func field_Query_stats_args(rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	args := map[string]interface{}{}
+	var arg0 string
+	if tmp, ok := rawArgs["name"]; ok {
+		var err error
+		arg0, err = graphql.UnmarshalString(tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["name"] = arg0
+	var arg1 time.Time
+	if tmp, ok := rawArgs["from"]; ok {
+		var err error
+		arg1, err = graphql.UnmarshalTime(tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["from"] = arg1
+	var arg2 time.Time
+	if tmp, ok := rawArgs["to"]; ok {
+		var err error
+		arg2, err = graphql.UnmarshalTime(tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["to"] = arg2
+	var arg3 Granularity
+	if tmp, ok := rawArgs["granularity"]; ok {
+		var err error
+		err = (&arg3).UnmarshalGQL(tmp)
+		if err != nil {
+			return nil, err
 		}
+	}
+	args["granularity"] = arg3
+	return args, nil
 
-		return e.complexity.TestInstance.Pass(childComplexity), true
+}
 
-	case "TestInstance.fail_cause":
-		if e.complexity.TestInstance.FailCause == nil {
-			break
+func field_Query_slo_args(rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	args := map[string]interface{}{}
+	var arg0 string
+	if tmp, ok := rawArgs["name"]; ok {
+		var err error
+		arg0, err = graphql.UnmarshalString(tmp)
+		if err != nil {
+			return nil, err
 		}
+	}
+	args["name"] = arg0
+	return args, nil
 
-		return e.complexity.TestInstance.FailCause(childComplexity), true
+}
+
+func field_Query_alertHistory_args(rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	args := map[string]interface{}{}
+	var arg0 string
+	if tmp, ok := rawArgs["name"]; ok {
+		var err error
+		arg0, err = graphql.UnmarshalString(tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["name"] = arg0
+	var arg1 *time.Time
+	if tmp, ok := rawArgs["from"]; ok {
+		var err error
+		var ptr1 time.Time
+		if tmp != nil {
+			ptr1, err = graphql.UnmarshalTime(tmp)
+			arg1 = &ptr1
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["from"] = arg1
+	var arg2 *time.Time
+	if tmp, ok := rawArgs["to"]; ok {
+		var err error
+		var ptr1 time.Time
+		if tmp != nil {
+			ptr1, err = graphql.UnmarshalTime(tmp)
+			arg2 = &ptr1
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["to"] = arg2
+	return args, nil
+
+}
+
+func field_Query_silences_args(rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	args := map[string]interface{}{}
+	var arg0 *bool
+	if tmp, ok := rawArgs["active"]; ok {
+		var err error
+		var ptr1 bool
+		if tmp != nil {
+			ptr1, err = graphql.UnmarshalBoolean(tmp)
+			arg0 = &ptr1
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["active"] = arg0
+	return args, nil
+
+}
+
+func field_Query___type_args(rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	args := map[string]interface{}{}
+	var arg0 string
+	if tmp, ok := rawArgs["name"]; ok {
+		var err error
+		arg0, err = graphql.UnmarshalString(tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["name"] = arg0
+	return args, nil
+
+}
+
+func field_Subscription_runFinished_args(rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	args := map[string]interface{}{}
+	var arg0 *string
+	if tmp, ok := rawArgs["name"]; ok {
+		var err error
+		var ptr1 string
+		if tmp != nil {
+			ptr1, err = graphql.UnmarshalString(tmp)
+			arg0 = &ptr1
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["name"] = arg0
+	return args, nil
+
+}
+
+func field_Subscription_runLog_args(rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	args := map[string]interface{}{}
+	var arg0 string
+	if tmp, ok := rawArgs["id"]; ok {
+		var err error
+		arg0, err = graphql.UnmarshalID(tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["id"] = arg0
+	return args, nil
+
+}
+
+func field_TestDefinition_runs_args(rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	args := map[string]interface{}{}
+	var arg0 *bool
+	if tmp, ok := rawArgs["pass"]; ok {
+		var err error
+		var ptr1 bool
+		if tmp != nil {
+			ptr1, err = graphql.UnmarshalBoolean(tmp)
+			arg0 = &ptr1
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["pass"] = arg0
+	var arg1 *FailureKind
+	if tmp, ok := rawArgs["fail_kind"]; ok {
+		var err error
+		var ptr1 FailureKind
+		if tmp != nil {
+			err = (&ptr1).UnmarshalGQL(tmp)
+			arg1 = &ptr1
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["fail_kind"] = arg1
+	var arg2 *time.Time
+	if tmp, ok := rawArgs["from"]; ok {
+		var err error
+		var ptr1 time.Time
+		if tmp != nil {
+			ptr1, err = graphql.UnmarshalTime(tmp)
+			arg2 = &ptr1
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["from"] = arg2
+	var arg3 *time.Time
+	if tmp, ok := rawArgs["to"]; ok {
+		var err error
+		var ptr1 time.Time
+		if tmp != nil {
+			ptr1, err = graphql.UnmarshalTime(tmp)
+			arg3 = &ptr1
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["to"] = arg3
+	var arg4 int
+	if tmp, ok := rawArgs["first"]; ok {
+		var err error
+		arg4, err = graphql.UnmarshalInt(tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["first"] = arg4
+	var arg5 *string
+	if tmp, ok := rawArgs["after"]; ok {
+		var err error
+		var ptr1 string
+		if tmp != nil {
+			ptr1, err = graphql.UnmarshalString(tmp)
+			arg5 = &ptr1
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["after"] = arg5
+	return args, nil
+
+}
+
+func field___Type_fields_args(rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	args := map[string]interface{}{}
+	var arg0 bool
+	if tmp, ok := rawArgs["includeDeprecated"]; ok {
+		var err error
+		arg0, err = graphql.UnmarshalBoolean(tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["includeDeprecated"] = arg0
+	return args, nil
+
+}
+
+func field___Type_enumValues_args(rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	args := map[string]interface{}{}
+	var arg0 bool
+	if tmp, ok := rawArgs["includeDeprecated"]; ok {
+		var err error
+		arg0, err = graphql.UnmarshalBoolean(tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["includeDeprecated"] = arg0
+	return args, nil
+
+}
+
+type executableSchema struct {
+	resolvers  ResolverRoot
+	directives DirectiveRoot
+	complexity ComplexityRoot
+}
+
+func (e *executableSchema) Schema() *ast.Schema {
+	return parsedSchema
+}
+
+func (e *executableSchema) Complexity(typeName, field string, childComplexity int, rawArgs map[string]interface{}) (int, bool) {
+	switch typeName + "." + field {
+
+	case "Alert.name":
+		if e.complexity.Alert.Name == nil {
+			break
+		}
+
+		return e.complexity.Alert.Name(childComplexity), true
+
+	case "Alert.state":
+		if e.complexity.Alert.State == nil {
+			break
+		}
+
+		return e.complexity.Alert.State(childComplexity), true
+
+	case "Alert.since":
+		if e.complexity.Alert.Since == nil {
+			break
+		}
+
+		return e.complexity.Alert.Since(childComplexity), true
+
+	case "Alert.flapping":
+		if e.complexity.Alert.Flapping == nil {
+			break
+		}
+
+		return e.complexity.Alert.Flapping(childComplexity), true
+
+	case "Alert.consecutive_failures":
+		if e.complexity.Alert.ConsecutiveFailures == nil {
+			break
+		}
+
+		return e.complexity.Alert.ConsecutiveFailures(childComplexity), true
+
+	case "Alert.consecutive_passes":
+		if e.complexity.Alert.ConsecutivePasses == nil {
+			break
+		}
+
+		return e.complexity.Alert.ConsecutivePasses(childComplexity), true
+
+	case "Alert.last_run":
+		if e.complexity.Alert.LastRun == nil {
+			break
+		}
+
+		return e.complexity.Alert.LastRun(childComplexity), true
+
+	case "AlertHistory.name":
+		if e.complexity.AlertHistory.Name == nil {
+			break
+		}
+
+		return e.complexity.AlertHistory.Name(childComplexity), true
+
+	case "AlertHistory.from":
+		if e.complexity.AlertHistory.From == nil {
+			break
+		}
+
+		return e.complexity.AlertHistory.From(childComplexity), true
+
+	case "AlertHistory.to":
+		if e.complexity.AlertHistory.To == nil {
+			break
+		}
+
+		return e.complexity.AlertHistory.To(childComplexity), true
+
+	case "AlertHistory.transitions":
+		if e.complexity.AlertHistory.Transitions == nil {
+			break
+		}
+
+		return e.complexity.AlertHistory.Transitions(childComplexity), true
+
+	case "AlertHistory.deliveries":
+		if e.complexity.AlertHistory.Deliveries == nil {
+			break
+		}
+
+		return e.complexity.AlertHistory.Deliveries(childComplexity), true
+
+	case "AlertTransition.at":
+		if e.complexity.AlertTransition.At == nil {
+			break
+		}
+
+		return e.complexity.AlertTransition.At(childComplexity), true
+
+	case "AlertTransition.state":
+		if e.complexity.AlertTransition.State == nil {
+			break
+		}
+
+		return e.complexity.AlertTransition.State(childComplexity), true
+
+	case "AlertTransition.flapping":
+		if e.complexity.AlertTransition.Flapping == nil {
+			break
+		}
+
+		return e.complexity.AlertTransition.Flapping(childComplexity), true
+
+	case "AlertTransition.run":
+		if e.complexity.AlertTransition.Run == nil {
+			break
+		}
+
+		return e.complexity.AlertTransition.Run(childComplexity), true
+
+	case "BurnRate.window":
+		if e.complexity.BurnRate.Window == nil {
+			break
+		}
+
+		return e.complexity.BurnRate.Window(childComplexity), true
+
+	case "BurnRate.runs":
+		if e.complexity.BurnRate.Runs == nil {
+			break
+		}
+
+		return e.complexity.BurnRate.Runs(childComplexity), true
+
+	case "BurnRate.success_ratio":
+		if e.complexity.BurnRate.SuccessRatio == nil {
+			break
+		}
+
+		return e.complexity.BurnRate.SuccessRatio(childComplexity), true
+
+	case "BurnRate.rate":
+		if e.complexity.BurnRate.Rate == nil {
+			break
+		}
+
+		return e.complexity.BurnRate.Rate(childComplexity), true
+
+	case "DeliveryAttempt.delivery_id":
+		if e.complexity.DeliveryAttempt.DeliveryId == nil {
+			break
+		}
+
+		return e.complexity.DeliveryAttempt.DeliveryId(childComplexity), true
+
+	case "DeliveryAttempt.notifier":
+		if e.complexity.DeliveryAttempt.Notifier == nil {
+			break
+		}
+
+		return e.complexity.DeliveryAttempt.Notifier(childComplexity), true
+
+	case "DeliveryAttempt.attempt":
+		if e.complexity.DeliveryAttempt.Attempt == nil {
+			break
+		}
+
+		return e.complexity.DeliveryAttempt.Attempt(childComplexity), true
+
+	case "DeliveryAttempt.at":
+		if e.complexity.DeliveryAttempt.At == nil {
+			break
+		}
+
+		return e.complexity.DeliveryAttempt.At(childComplexity), true
+
+	case "DeliveryAttempt.latency":
+		if e.complexity.DeliveryAttempt.Latency == nil {
+			break
+		}
+
+		return e.complexity.DeliveryAttempt.Latency(childComplexity), true
+
+	case "DeliveryAttempt.status_code":
+		if e.complexity.DeliveryAttempt.StatusCode == nil {
+			break
+		}
+
+		return e.complexity.DeliveryAttempt.StatusCode(childComplexity), true
+
+	case "DeliveryAttempt.error":
+		if e.complexity.DeliveryAttempt.Error == nil {
+			break
+		}
+
+		return e.complexity.DeliveryAttempt.Error(childComplexity), true
+
+	case "DeliveryAttempt.final":
+		if e.complexity.DeliveryAttempt.Final == nil {
+			break
+		}
+
+		return e.complexity.DeliveryAttempt.Final(childComplexity), true
+
+	case "DeliveryAttempt.silenced_by":
+		if e.complexity.DeliveryAttempt.SilencedBy == nil {
+			break
+		}
+
+		return e.complexity.DeliveryAttempt.SilencedBy(childComplexity), true
+
+	case "DeliveryAttempt.run":
+		if e.complexity.DeliveryAttempt.Run == nil {
+			break
+		}
+
+		return e.complexity.DeliveryAttempt.Run(childComplexity), true
+
+	case "Label.name":
+		if e.complexity.Label.Name == nil {
+			break
+		}
+
+		return e.complexity.Label.Name(childComplexity), true
+
+	case "Label.value":
+		if e.complexity.Label.Value == nil {
+			break
+		}
+
+		return e.complexity.Label.Value(childComplexity), true
+
+	case "LogEntry.time":
+		if e.complexity.LogEntry.Time == nil {
+			break
+		}
+
+		return e.complexity.LogEntry.Time(childComplexity), true
+
+	case "LogEntry.level":
+		if e.complexity.LogEntry.Level == nil {
+			break
+		}
+
+		return e.complexity.LogEntry.Level(childComplexity), true
+
+	case "LogEntry.message":
+		if e.complexity.LogEntry.Message == nil {
+			break
+		}
+
+		return e.complexity.LogEntry.Message(childComplexity), true
+
+	case "LogEntry.fields":
+		if e.complexity.LogEntry.Fields == nil {
+			break
+		}
+
+		return e.complexity.LogEntry.Fields(childComplexity), true
+
+	case "LogField.key":
+		if e.complexity.LogField.Key == nil {
+			break
+		}
+
+		return e.complexity.LogField.Key(childComplexity), true
+
+	case "LogField.value":
+		if e.complexity.LogField.Value == nil {
+			break
+		}
+
+		return e.complexity.LogField.Value(childComplexity), true
+
+	case "Mutation.pauseTest":
+		if e.complexity.Mutation.PauseTest == nil {
+			break
+		}
+
+		args, err := field_Mutation_pauseTest_args(rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.PauseTest(childComplexity, args["name"].(string), args["until"].(*time.Time), args["reason"].(string)), true
+
+	case "Mutation.resumeTest":
+		if e.complexity.Mutation.ResumeTest == nil {
+			break
+		}
+
+		args, err := field_Mutation_resumeTest_args(rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.ResumeTest(childComplexity, args["name"].(string)), true
+
+	case "Mutation.createSilence":
+		if e.complexity.Mutation.CreateSilence == nil {
+			break
+		}
+
+		args, err := field_Mutation_createSilence_args(rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.CreateSilence(childComplexity, args["tests"].(*string), args["labels"].([]LabelInput), args["start_at"].(*time.Time), args["end_at"].(time.Time), args["comment"].(string)), true
+
+	case "Mutation.expireSilence":
+		if e.complexity.Mutation.ExpireSilence == nil {
+			break
+		}
+
+		args, err := field_Mutation_expireSilence_args(rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.ExpireSilence(childComplexity, args["id"].(string)), true
+
+	case "PageInfo.hasNextPage":
+		if e.complexity.PageInfo.HasNextPage == nil {
+			break
+		}
+
+		return e.complexity.PageInfo.HasNextPage(childComplexity), true
+
+	case "PageInfo.hasPreviousPage":
+		if e.complexity.PageInfo.HasPreviousPage == nil {
+			break
+		}
+
+		return e.complexity.PageInfo.HasPreviousPage(childComplexity), true
+
+	case "PageInfo.startCursor":
+		if e.complexity.PageInfo.StartCursor == nil {
+			break
+		}
+
+		return e.complexity.PageInfo.StartCursor(childComplexity), true
+
+	case "PageInfo.endCursor":
+		if e.complexity.PageInfo.EndCursor == nil {
+			break
+		}
+
+		return e.complexity.PageInfo.EndCursor(childComplexity), true
+
+	case "Pause.until":
+		if e.complexity.Pause.Until == nil {
+			break
+		}
+
+		return e.complexity.Pause.Until(childComplexity), true
+
+	case "Pause.reason":
+		if e.complexity.Pause.Reason == nil {
+			break
+		}
+
+		return e.complexity.Pause.Reason(childComplexity), true
+
+	case "Pause.paused_by":
+		if e.complexity.Pause.PausedBy == nil {
+			break
+		}
+
+		return e.complexity.Pause.PausedBy(childComplexity), true
+
+	case "Pause.paused_at":
+		if e.complexity.Pause.PausedAt == nil {
+			break
+		}
+
+		return e.complexity.Pause.PausedAt(childComplexity), true
+
+	case "Query.runs":
+		if e.complexity.Query.Runs == nil {
+			break
+		}
+
+		args, err := field_Query_runs_args(rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.Runs(childComplexity, args["name"].(*string), args["pass"].(*bool), args["fail_kind"].(*FailureKind), args["from"].(*time.Time), args["to"].(*time.Time), args["first"].(int), args["after"].(*string)), true
+
+	case "Query.test":
+		if e.complexity.Query.Test == nil {
+			break
+		}
+
+		args, err := field_Query_test_args(rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.Test(childComplexity, args["id"].(string)), true
+
+	case "Query.ongoingTests":
+		if e.complexity.Query.OngoingTests == nil {
+			break
+		}
+
+		return e.complexity.Query.OngoingTests(childComplexity), true
+
+	case "Query.definitions":
+		if e.complexity.Query.Definitions == nil {
+			break
+		}
+
+		return e.complexity.Query.Definitions(childComplexity), true
+
+	case "Query.definition":
+		if e.complexity.Query.Definition == nil {
+			break
+		}
+
+		args, err := field_Query_definition_args(rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.Definition(childComplexity, args["name"].(string)), true
+
+	case "Query.stats":
+		if e.complexity.Query.Stats == nil {
+			break
+		}
+
+		args, err := field_Query_stats_args(rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.Stats(childComplexity, args["name"].(string), args["from"].(time.Time), args["to"].(time.Time), args["granularity"].(Granularity)), true
+
+	case "Query.slo":
+		if e.complexity.Query.Slo == nil {
+			break
+		}
+
+		args, err := field_Query_slo_args(rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.Slo(childComplexity, args["name"].(string)), true
+
+	case "Query.alerts":
+		if e.complexity.Query.Alerts == nil {
+			break
+		}
+
+		return e.complexity.Query.Alerts(childComplexity), true
+
+	case "Query.alertHistory":
+		if e.complexity.Query.AlertHistory == nil {
+			break
+		}
+
+		args, err := field_Query_alertHistory_args(rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.AlertHistory(childComplexity, args["name"].(string), args["from"].(*time.Time), args["to"].(*time.Time)), true
+
+	case "Query.silences":
+		if e.complexity.Query.Silences == nil {
+			break
+		}
+
+		args, err := field_Query_silences_args(rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.Silences(childComplexity, args["active"].(*bool)), true
+
+	case "Rollup.name":
+		if e.complexity.Rollup.Name == nil {
+			break
+		}
+
+		return e.complexity.Rollup.Name(childComplexity), true
+
+	case "Rollup.granularity":
+		if e.complexity.Rollup.Granularity == nil {
+			break
+		}
+
+		return e.complexity.Rollup.Granularity(childComplexity), true
+
+	case "Rollup.start_at":
+		if e.complexity.Rollup.StartAt == nil {
+			break
+		}
+
+		return e.complexity.Rollup.StartAt(childComplexity), true
+
+	case "Rollup.runs":
+		if e.complexity.Rollup.Runs == nil {
+			break
+		}
+
+		return e.complexity.Rollup.Runs(childComplexity), true
+
+	case "Rollup.passes":
+		if e.complexity.Rollup.Passes == nil {
+			break
+		}
+
+		return e.complexity.Rollup.Passes(childComplexity), true
+
+	case "Rollup.failures":
+		if e.complexity.Rollup.Failures == nil {
+			break
+		}
+
+		return e.complexity.Rollup.Failures(childComplexity), true
+
+	case "Rollup.min_duration":
+		if e.complexity.Rollup.MinDuration == nil {
+			break
+		}
+
+		return e.complexity.Rollup.MinDuration(childComplexity), true
+
+	case "Rollup.avg_duration":
+		if e.complexity.Rollup.AvgDuration == nil {
+			break
+		}
+
+		return e.complexity.Rollup.AvgDuration(childComplexity), true
+
+	case "Rollup.max_duration":
+		if e.complexity.Rollup.MaxDuration == nil {
+			break
+		}
+
+		return e.complexity.Rollup.MaxDuration(childComplexity), true
+
+	case "Rollup.p95_duration":
+		if e.complexity.Rollup.P95Duration == nil {
+			break
+		}
+
+		return e.complexity.Rollup.P95Duration(childComplexity), true
+
+	case "SLOReport.name":
+		if e.complexity.Sloreport.Name == nil {
+			break
+		}
+
+		return e.complexity.Sloreport.Name(childComplexity), true
+
+	case "SLOReport.target":
+		if e.complexity.Sloreport.Target == nil {
+			break
+		}
+
+		return e.complexity.Sloreport.Target(childComplexity), true
+
+	case "SLOReport.window":
+		if e.complexity.Sloreport.Window == nil {
+			break
+		}
+
+		return e.complexity.Sloreport.Window(childComplexity), true
+
+	case "SLOReport.success_ratio":
+		if e.complexity.Sloreport.SuccessRatio == nil {
+			break
+		}
+
+		return e.complexity.Sloreport.SuccessRatio(childComplexity), true
+
+	case "SLOReport.error_budget_remaining":
+		if e.complexity.Sloreport.ErrorBudgetRemaining == nil {
+			break
+		}
+
+		return e.complexity.Sloreport.ErrorBudgetRemaining(childComplexity), true
+
+	case "SLOReport.burn_rates":
+		if e.complexity.Sloreport.BurnRates == nil {
+			break
+		}
+
+		return e.complexity.Sloreport.BurnRates(childComplexity), true
+
+	case "Silence.id":
+		if e.complexity.Silence.Id == nil {
+			break
+		}
+
+		return e.complexity.Silence.Id(childComplexity), true
+
+	case "Silence.tests":
+		if e.complexity.Silence.Tests == nil {
+			break
+		}
+
+		return e.complexity.Silence.Tests(childComplexity), true
+
+	case "Silence.labels":
+		if e.complexity.Silence.Labels == nil {
+			break
+		}
+
+		return e.complexity.Silence.Labels(childComplexity), true
+
+	case "Silence.start_at":
+		if e.complexity.Silence.StartAt == nil {
+			break
+		}
+
+		return e.complexity.Silence.StartAt(childComplexity), true
+
+	case "Silence.end_at":
+		if e.complexity.Silence.EndAt == nil {
+			break
+		}
+
+		return e.complexity.Silence.EndAt(childComplexity), true
+
+	case "Silence.comment":
+		if e.complexity.Silence.Comment == nil {
+			break
+		}
+
+		return e.complexity.Silence.Comment(childComplexity), true
+
+	case "Silence.created_by":
+		if e.complexity.Silence.CreatedBy == nil {
+			break
+		}
+
+		return e.complexity.Silence.CreatedBy(childComplexity), true
+
+	case "Silence.created_at":
+		if e.complexity.Silence.CreatedAt == nil {
+			break
+		}
+
+		return e.complexity.Silence.CreatedAt(childComplexity), true
+
+	case "Silence.active":
+		if e.complexity.Silence.Active == nil {
+			break
+		}
+
+		return e.complexity.Silence.Active(childComplexity), true
+
+	case "Subscription.runStarted":
+		if e.complexity.Subscription.RunStarted == nil {
+			break
+		}
+
+		return e.complexity.Subscription.RunStarted(childComplexity), true
+
+	case "Subscription.runFinished":
+		if e.complexity.Subscription.RunFinished == nil {
+			break
+		}
+
+		args, err := field_Subscription_runFinished_args(rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Subscription.RunFinished(childComplexity, args["name"].(*string)), true
+
+	case "Subscription.runLog":
+		if e.complexity.Subscription.RunLog == nil {
+			break
+		}
+
+		args, err := field_Subscription_runLog_args(rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Subscription.RunLog(childComplexity, args["id"].(string)), true
+
+	case "TestDefinition.name":
+		if e.complexity.TestDefinition.Name == nil {
+			break
+		}
+
+		return e.complexity.TestDefinition.Name(childComplexity), true
+
+	case "TestDefinition.canary":
+		if e.complexity.TestDefinition.Canary == nil {
+			break
+		}
+
+		return e.complexity.TestDefinition.Canary(childComplexity), true
+
+	case "TestDefinition.frequency":
+		if e.complexity.TestDefinition.Frequency == nil {
+			break
+		}
+
+		return e.complexity.TestDefinition.Frequency(childComplexity), true
+
+	case "TestDefinition.timeout":
+		if e.complexity.TestDefinition.Timeout == nil {
+			break
+		}
+
+		return e.complexity.TestDefinition.Timeout(childComplexity), true
+
+	case "TestDefinition.source":
+		if e.complexity.TestDefinition.Source == nil {
+			break
+		}
+
+		return e.complexity.TestDefinition.Source(childComplexity), true
+
+	case "TestDefinition.labels":
+		if e.complexity.TestDefinition.Labels == nil {
+			break
+		}
+
+		return e.complexity.TestDefinition.Labels(childComplexity), true
+
+	case "TestDefinition.slo":
+		if e.complexity.TestDefinition.Slo == nil {
+			break
+		}
+
+		return e.complexity.TestDefinition.Slo(childComplexity), true
+
+	case "TestDefinition.status":
+		if e.complexity.TestDefinition.Status == nil {
+			break
+		}
+
+		return e.complexity.TestDefinition.Status(childComplexity), true
+
+	case "TestDefinition.last_run":
+		if e.complexity.TestDefinition.LastRun == nil {
+			break
+		}
+
+		return e.complexity.TestDefinition.LastRun(childComplexity), true
+
+	case "TestDefinition.last_success":
+		if e.complexity.TestDefinition.LastSuccess == nil {
+			break
+		}
+
+		return e.complexity.TestDefinition.LastSuccess(childComplexity), true
+
+	case "TestDefinition.next_run_at":
+		if e.complexity.TestDefinition.NextRunAt == nil {
+			break
+		}
+
+		return e.complexity.TestDefinition.NextRunAt(childComplexity), true
+
+	case "TestDefinition.pause":
+		if e.complexity.TestDefinition.Pause == nil {
+			break
+		}
+
+		return e.complexity.TestDefinition.Pause(childComplexity), true
+
+	case "TestDefinition.runs":
+		if e.complexity.TestDefinition.Runs == nil {
+			break
+		}
+
+		args, err := field_TestDefinition_runs_args(rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.TestDefinition.Runs(childComplexity, args["pass"].(*bool), args["fail_kind"].(*FailureKind), args["from"].(*time.Time), args["to"].(*time.Time), args["first"].(int), args["after"].(*string)), true
+
+	case "TestInstance.id":
+		if e.complexity.TestInstance.Id == nil {
+			break
+		}
+
+		return e.complexity.TestInstance.Id(childComplexity), true
+
+	case "TestInstance.name":
+		if e.complexity.TestInstance.Name == nil {
+			break
+		}
+
+		return e.complexity.TestInstance.Name(childComplexity), true
+
+	case "TestInstance.start_at":
+		if e.complexity.TestInstance.StartAt == nil {
+			break
+		}
+
+		return e.complexity.TestInstance.StartAt(childComplexity), true
+
+	case "TestInstance.end_at":
+		if e.complexity.TestInstance.EndAt == nil {
+			break
+		}
+
+		return e.complexity.TestInstance.EndAt(childComplexity), true
+
+	case "TestInstance.pass":
+		if e.complexity.TestInstance.Pass == nil {
+			break
+		}
+
+		return e.complexity.TestInstance.Pass(childComplexity), true
+
+	case "TestInstance.fail_cause":
+		if e.complexity.TestInstance.FailCause == nil {
+			break
+		}
+
+		return e.complexity.TestInstance.FailCause(childComplexity), true
+
+	case "TestInstance.fail_kind":
+		if e.complexity.TestInstance.FailKind == nil {
+			break
+		}
+
+		return e.complexity.TestInstance.FailKind(childComplexity), true
+
+	case "TestInstanceConnection.edges":
+		if e.complexity.TestInstanceConnection.Edges == nil {
+			break
+		}
+
+		return e.complexity.TestInstanceConnection.Edges(childComplexity), true
+
+	case "TestInstanceConnection.pageInfo":
+		if e.complexity.TestInstanceConnection.PageInfo == nil {
+			break
+		}
+
+		return e.complexity.TestInstanceConnection.PageInfo(childComplexity), true
+
+	case "TestInstanceConnection.totalCount":
+		if e.complexity.TestInstanceConnection.TotalCount == nil {
+			break
+		}
+
+		return e.complexity.TestInstanceConnection.TotalCount(childComplexity), true
+
+	case "TestInstanceEdge.cursor":
+		if e.complexity.TestInstanceEdge.Cursor == nil {
+			break
+		}
+
+		return e.complexity.TestInstanceEdge.Cursor(childComplexity), true
+
+	case "TestInstanceEdge.node":
+		if e.complexity.TestInstanceEdge.Node == nil {
+			break
+		}
+
+		return e.complexity.TestInstanceEdge.Node(childComplexity), true
+
+	}
+	return 0, false
+}
+
+func (e *executableSchema) Query(ctx context.Context, op *ast.OperationDefinition) *graphql.Response {
+	ec := executionContext{graphql.GetRequestContext(ctx), e}
+
+	buf := ec.RequestMiddleware(ctx, func(ctx context.Context) []byte {
+		data := ec._Query(ctx, op.SelectionSet)
+		var buf bytes.Buffer
+		data.MarshalGQL(&buf)
+		return buf.Bytes()
+	})
+
+	return &graphql.Response{
+		Data:   buf,
+		Errors: ec.Errors,
+	}
+}
+
+func (e *executableSchema) Mutation(ctx context.Context, op *ast.OperationDefinition) *graphql.Response {
+	ec := executionContext{graphql.GetRequestContext(ctx), e}
+
+	buf := ec.RequestMiddleware(ctx, func(ctx context.Context) []byte {
+		data := ec._Mutation(ctx, op.SelectionSet)
+		var buf bytes.Buffer
+		data.MarshalGQL(&buf)
+		return buf.Bytes()
+	})
+
+	return &graphql.Response{
+		Data:   buf,
+		Errors: ec.Errors,
+	}
+}
+
+func (e *executableSchema) Subscription(ctx context.Context, op *ast.OperationDefinition) func() *graphql.Response {
+	ec := executionContext{graphql.GetRequestContext(ctx), e}
+
+	next := ec._Subscription(ctx, op.SelectionSet)
+	if ec.Errors != nil {
+		return graphql.OneShot(&graphql.Response{Data: []byte("null"), Errors: ec.Errors})
+	}
+
+	var buf bytes.Buffer
+	return func() *graphql.Response {
+		buf := ec.RequestMiddleware(ctx, func(ctx context.Context) []byte {
+			buf.Reset()
+			data := next()
+
+			if data == nil {
+				return nil
+			}
+			data.MarshalGQL(&buf)
+			return buf.Bytes()
+		})
+
+		if buf == nil {
+			return nil
+		}
+
+		return &graphql.Response{
+			Data:   buf,
+			Errors: ec.Errors,
+		}
+	}
+}
+
+type executionContext struct {
+	*graphql.RequestContext
+	*executableSchema
+}
+
+var alertImplementors = []string{"Alert"}
+
+// nolint: gocyclo, errcheck, gas, goconst
+func (ec *executionContext) _Alert(ctx context.Context, sel ast.SelectionSet, obj *db.AlertState) graphql.Marshaler {
+	fields := graphql.CollectFields(ctx, sel, alertImplementors)
+
+	var wg sync.WaitGroup
+	out := graphql.NewOrderedMap(len(fields))
+	invalid := false
+	for i, field := range fields {
+		out.Keys[i] = field.Alias
+
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("Alert")
+		case "name":
+			wg.Add(1)
+			go func(i int, field graphql.CollectedField) {
+				out.Values[i] = ec._Alert_name(ctx, field, obj)
+				if out.Values[i] == graphql.Null {
+					invalid = true
+				}
+				wg.Done()
+			}(i, field)
+		case "state":
+			wg.Add(1)
+			go func(i int, field graphql.CollectedField) {
+				out.Values[i] = ec._Alert_state(ctx, field, obj)
+				if out.Values[i] == graphql.Null {
+					invalid = true
+				}
+				wg.Done()
+			}(i, field)
+		case "since":
+			wg.Add(1)
+			go func(i int, field graphql.CollectedField) {
+				out.Values[i] = ec._Alert_since(ctx, field, obj)
+				wg.Done()
+			}(i, field)
+		case "flapping":
+			out.Values[i] = ec._Alert_flapping(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalid = true
+			}
+		case "consecutive_failures":
+			wg.Add(1)
+			go func(i int, field graphql.CollectedField) {
+				out.Values[i] = ec._Alert_consecutive_failures(ctx, field, obj)
+				if out.Values[i] == graphql.Null {
+					invalid = true
+				}
+				wg.Done()
+			}(i, field)
+		case "consecutive_passes":
+			wg.Add(1)
+			go func(i int, field graphql.CollectedField) {
+				out.Values[i] = ec._Alert_consecutive_passes(ctx, field, obj)
+				if out.Values[i] == graphql.Null {
+					invalid = true
+				}
+				wg.Done()
+			}(i, field)
+		case "last_run":
+			wg.Add(1)
+			go func(i int, field graphql.CollectedField) {
+				out.Values[i] = ec._Alert_last_run(ctx, field, obj)
+				wg.Done()
+			}(i, field)
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	wg.Wait()
+	if invalid {
+		return graphql.Null
+	}
+	return out
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _Alert_name(ctx context.Context, field graphql.CollectedField, obj *db.AlertState) graphql.Marshaler {
+	rctx := &graphql.ResolverContext{
+		Object: "Alert",
+		Args:   nil,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return ec.resolvers.Alert().Name(ctx, obj)
+	})
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	rctx.Result = res
+	return graphql.MarshalString(res)
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _Alert_state(ctx context.Context, field graphql.CollectedField, obj *db.AlertState) graphql.Marshaler {
+	rctx := &graphql.ResolverContext{
+		Object: "Alert",
+		Args:   nil,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return ec.resolvers.Alert().State(ctx, obj)
+	})
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(AlertState)
+	rctx.Result = res
+	return res
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _Alert_since(ctx context.Context, field graphql.CollectedField, obj *db.AlertState) graphql.Marshaler {
+	rctx := &graphql.ResolverContext{
+		Object: "Alert",
+		Args:   nil,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return ec.resolvers.Alert().Since(ctx, obj)
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*time.Time)
+	rctx.Result = res
+
+	if res == nil {
+		return graphql.Null
+	}
+	return graphql.MarshalTime(*res)
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _Alert_flapping(ctx context.Context, field graphql.CollectedField, obj *db.AlertState) graphql.Marshaler {
+	rctx := &graphql.ResolverContext{
+		Object: "Alert",
+		Args:   nil,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return obj.Flapping, nil
+	})
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(bool)
+	rctx.Result = res
+	return graphql.MarshalBoolean(res)
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _Alert_consecutive_failures(ctx context.Context, field graphql.CollectedField, obj *db.AlertState) graphql.Marshaler {
+	rctx := &graphql.ResolverContext{
+		Object: "Alert",
+		Args:   nil,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return ec.resolvers.Alert().ConsecutiveFailures(ctx, obj)
+	})
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(int)
+	rctx.Result = res
+	return graphql.MarshalInt(res)
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _Alert_consecutive_passes(ctx context.Context, field graphql.CollectedField, obj *db.AlertState) graphql.Marshaler {
+	rctx := &graphql.ResolverContext{
+		Object: "Alert",
+		Args:   nil,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return ec.resolvers.Alert().ConsecutivePasses(ctx, obj)
+	})
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(int)
+	rctx.Result = res
+	return graphql.MarshalInt(res)
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _Alert_last_run(ctx context.Context, field graphql.CollectedField, obj *db.AlertState) graphql.Marshaler {
+	rctx := &graphql.ResolverContext{
+		Object: "Alert",
+		Args:   nil,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return ec.resolvers.Alert().LastRun(ctx, obj)
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*db.TestInstance)
+	rctx.Result = res
+
+	if res == nil {
+		return graphql.Null
+	}
+
+	return ec._TestInstance(ctx, field.Selections, res)
+}
+
+var alertHistoryImplementors = []string{"AlertHistory"}
+
+// nolint: gocyclo, errcheck, gas, goconst
+func (ec *executionContext) _AlertHistory(ctx context.Context, sel ast.SelectionSet, obj *AlertHistory) graphql.Marshaler {
+	fields := graphql.CollectFields(ctx, sel, alertHistoryImplementors)
+
+	out := graphql.NewOrderedMap(len(fields))
+	invalid := false
+	for i, field := range fields {
+		out.Keys[i] = field.Alias
+
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("AlertHistory")
+		case "name":
+			out.Values[i] = ec._AlertHistory_name(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalid = true
+			}
+		case "from":
+			out.Values[i] = ec._AlertHistory_from(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalid = true
+			}
+		case "to":
+			out.Values[i] = ec._AlertHistory_to(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalid = true
+			}
+		case "transitions":
+			out.Values[i] = ec._AlertHistory_transitions(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalid = true
+			}
+		case "deliveries":
+			out.Values[i] = ec._AlertHistory_deliveries(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalid = true
+			}
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+
+	if invalid {
+		return graphql.Null
+	}
+	return out
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _AlertHistory_name(ctx context.Context, field graphql.CollectedField, obj *AlertHistory) graphql.Marshaler {
+	rctx := &graphql.ResolverContext{
+		Object: "AlertHistory",
+		Args:   nil,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return obj.Name, nil
+	})
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	rctx.Result = res
+	return graphql.MarshalString(res)
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _AlertHistory_from(ctx context.Context, field graphql.CollectedField, obj *AlertHistory) graphql.Marshaler {
+	rctx := &graphql.ResolverContext{
+		Object: "AlertHistory",
+		Args:   nil,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return obj.From, nil
+	})
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(time.Time)
+	rctx.Result = res
+	return graphql.MarshalTime(res)
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _AlertHistory_to(ctx context.Context, field graphql.CollectedField, obj *AlertHistory) graphql.Marshaler {
+	rctx := &graphql.ResolverContext{
+		Object: "AlertHistory",
+		Args:   nil,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return obj.To, nil
+	})
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(time.Time)
+	rctx.Result = res
+	return graphql.MarshalTime(res)
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _AlertHistory_transitions(ctx context.Context, field graphql.CollectedField, obj *AlertHistory) graphql.Marshaler {
+	rctx := &graphql.ResolverContext{
+		Object: "AlertHistory",
+		Args:   nil,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return obj.Transitions, nil
+	})
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.([]db.AlertTransition)
+	rctx.Result = res
+
+	arr1 := make(graphql.Array, len(res))
+	var wg sync.WaitGroup
+
+	isLen1 := len(res) == 1
+	if !isLen1 {
+		wg.Add(len(res))
+	}
+
+	for idx1 := range res {
+		idx1 := idx1
+		rctx := &graphql.ResolverContext{
+			Index:  &idx1,
+			Result: &res[idx1],
+		}
+		ctx := graphql.WithResolverContext(ctx, rctx)
+		f := func(idx1 int) {
+			if !isLen1 {
+				defer wg.Done()
+			}
+			arr1[idx1] = func() graphql.Marshaler {
+
+				return ec._AlertTransition(ctx, field.Selections, &res[idx1])
+			}()
+		}
+		if isLen1 {
+			f(idx1)
+		} else {
+			go f(idx1)
+		}
+
+	}
+	wg.Wait()
+	return arr1
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _AlertHistory_deliveries(ctx context.Context, field graphql.CollectedField, obj *AlertHistory) graphql.Marshaler {
+	rctx := &graphql.ResolverContext{
+		Object: "AlertHistory",
+		Args:   nil,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return obj.Deliveries, nil
+	})
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.([]db.DeliveryAttempt)
+	rctx.Result = res
+
+	arr1 := make(graphql.Array, len(res))
+	var wg sync.WaitGroup
+
+	isLen1 := len(res) == 1
+	if !isLen1 {
+		wg.Add(len(res))
+	}
+
+	for idx1 := range res {
+		idx1 := idx1
+		rctx := &graphql.ResolverContext{
+			Index:  &idx1,
+			Result: &res[idx1],
+		}
+		ctx := graphql.WithResolverContext(ctx, rctx)
+		f := func(idx1 int) {
+			if !isLen1 {
+				defer wg.Done()
+			}
+			arr1[idx1] = func() graphql.Marshaler {
+
+				return ec._DeliveryAttempt(ctx, field.Selections, &res[idx1])
+			}()
+		}
+		if isLen1 {
+			f(idx1)
+		} else {
+			go f(idx1)
+		}
+
+	}
+	wg.Wait()
+	return arr1
+}
+
+var alertTransitionImplementors = []string{"AlertTransition"}
+
+// nolint: gocyclo, errcheck, gas, goconst
+func (ec *executionContext) _AlertTransition(ctx context.Context, sel ast.SelectionSet, obj *db.AlertTransition) graphql.Marshaler {
+	fields := graphql.CollectFields(ctx, sel, alertTransitionImplementors)
+
+	var wg sync.WaitGroup
+	out := graphql.NewOrderedMap(len(fields))
+	invalid := false
+	for i, field := range fields {
+		out.Keys[i] = field.Alias
+
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("AlertTransition")
+		case "at":
+			out.Values[i] = ec._AlertTransition_at(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalid = true
+			}
+		case "state":
+			wg.Add(1)
+			go func(i int, field graphql.CollectedField) {
+				out.Values[i] = ec._AlertTransition_state(ctx, field, obj)
+				if out.Values[i] == graphql.Null {
+					invalid = true
+				}
+				wg.Done()
+			}(i, field)
+		case "flapping":
+			out.Values[i] = ec._AlertTransition_flapping(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalid = true
+			}
+		case "run":
+			wg.Add(1)
+			go func(i int, field graphql.CollectedField) {
+				out.Values[i] = ec._AlertTransition_run(ctx, field, obj)
+				wg.Done()
+			}(i, field)
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	wg.Wait()
+	if invalid {
+		return graphql.Null
+	}
+	return out
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _AlertTransition_at(ctx context.Context, field graphql.CollectedField, obj *db.AlertTransition) graphql.Marshaler {
+	rctx := &graphql.ResolverContext{
+		Object: "AlertTransition",
+		Args:   nil,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return obj.At, nil
+	})
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(time.Time)
+	rctx.Result = res
+	return graphql.MarshalTime(res)
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _AlertTransition_state(ctx context.Context, field graphql.CollectedField, obj *db.AlertTransition) graphql.Marshaler {
+	rctx := &graphql.ResolverContext{
+		Object: "AlertTransition",
+		Args:   nil,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return ec.resolvers.AlertTransition().State(ctx, obj)
+	})
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(AlertState)
+	rctx.Result = res
+	return res
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _AlertTransition_flapping(ctx context.Context, field graphql.CollectedField, obj *db.AlertTransition) graphql.Marshaler {
+	rctx := &graphql.ResolverContext{
+		Object: "AlertTransition",
+		Args:   nil,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return obj.Flapping, nil
+	})
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(bool)
+	rctx.Result = res
+	return graphql.MarshalBoolean(res)
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _AlertTransition_run(ctx context.Context, field graphql.CollectedField, obj *db.AlertTransition) graphql.Marshaler {
+	rctx := &graphql.ResolverContext{
+		Object: "AlertTransition",
+		Args:   nil,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return ec.resolvers.AlertTransition().Run(ctx, obj)
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*db.TestInstance)
+	rctx.Result = res
+
+	if res == nil {
+		return graphql.Null
+	}
+
+	return ec._TestInstance(ctx, field.Selections, res)
+}
+
+var burnRateImplementors = []string{"BurnRate"}
+
+// nolint: gocyclo, errcheck, gas, goconst
+func (ec *executionContext) _BurnRate(ctx context.Context, sel ast.SelectionSet, obj *slo.BurnRate) graphql.Marshaler {
+	fields := graphql.CollectFields(ctx, sel, burnRateImplementors)
+
+	var wg sync.WaitGroup
+	out := graphql.NewOrderedMap(len(fields))
+	invalid := false
+	for i, field := range fields {
+		out.Keys[i] = field.Alias
+
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("BurnRate")
+		case "window":
+			wg.Add(1)
+			go func(i int, field graphql.CollectedField) {
+				out.Values[i] = ec._BurnRate_window(ctx, field, obj)
+				if out.Values[i] == graphql.Null {
+					invalid = true
+				}
+				wg.Done()
+			}(i, field)
+		case "runs":
+			out.Values[i] = ec._BurnRate_runs(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalid = true
+			}
+		case "success_ratio":
+			wg.Add(1)
+			go func(i int, field graphql.CollectedField) {
+				out.Values[i] = ec._BurnRate_success_ratio(ctx, field, obj)
+				if out.Values[i] == graphql.Null {
+					invalid = true
+				}
+				wg.Done()
+			}(i, field)
+		case "rate":
+			out.Values[i] = ec._BurnRate_rate(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalid = true
+			}
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	wg.Wait()
+	if invalid {
+		return graphql.Null
+	}
+	return out
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _BurnRate_window(ctx context.Context, field graphql.CollectedField, obj *slo.BurnRate) graphql.Marshaler {
+	rctx := &graphql.ResolverContext{
+		Object: "BurnRate",
+		Args:   nil,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return ec.resolvers.BurnRate().Window(ctx, obj)
+	})
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	rctx.Result = res
+	return graphql.MarshalString(res)
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _BurnRate_runs(ctx context.Context, field graphql.CollectedField, obj *slo.BurnRate) graphql.Marshaler {
+	rctx := &graphql.ResolverContext{
+		Object: "BurnRate",
+		Args:   nil,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return obj.Runs, nil
+	})
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(int)
+	rctx.Result = res
+	return graphql.MarshalInt(res)
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _BurnRate_success_ratio(ctx context.Context, field graphql.CollectedField, obj *slo.BurnRate) graphql.Marshaler {
+	rctx := &graphql.ResolverContext{
+		Object: "BurnRate",
+		Args:   nil,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return ec.resolvers.BurnRate().SuccessRatio(ctx, obj)
+	})
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(float64)
+	rctx.Result = res
+	return graphql.MarshalFloat(res)
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _BurnRate_rate(ctx context.Context, field graphql.CollectedField, obj *slo.BurnRate) graphql.Marshaler {
+	rctx := &graphql.ResolverContext{
+		Object: "BurnRate",
+		Args:   nil,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return obj.Rate, nil
+	})
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(float64)
+	rctx.Result = res
+	return graphql.MarshalFloat(res)
+}
+
+var deliveryAttemptImplementors = []string{"DeliveryAttempt"}
+
+// nolint: gocyclo, errcheck, gas, goconst
+func (ec *executionContext) _DeliveryAttempt(ctx context.Context, sel ast.SelectionSet, obj *db.DeliveryAttempt) graphql.Marshaler {
+	fields := graphql.CollectFields(ctx, sel, deliveryAttemptImplementors)
+
+	var wg sync.WaitGroup
+	out := graphql.NewOrderedMap(len(fields))
+	invalid := false
+	for i, field := range fields {
+		out.Keys[i] = field.Alias
+
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("DeliveryAttempt")
+		case "delivery_id":
+			wg.Add(1)
+			go func(i int, field graphql.CollectedField) {
+				out.Values[i] = ec._DeliveryAttempt_delivery_id(ctx, field, obj)
+				if out.Values[i] == graphql.Null {
+					invalid = true
+				}
+				wg.Done()
+			}(i, field)
+		case "notifier":
+			out.Values[i] = ec._DeliveryAttempt_notifier(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalid = true
+			}
+		case "attempt":
+			out.Values[i] = ec._DeliveryAttempt_attempt(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalid = true
+			}
+		case "at":
+			out.Values[i] = ec._DeliveryAttempt_at(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalid = true
+			}
+		case "latency":
+			wg.Add(1)
+			go func(i int, field graphql.CollectedField) {
+				out.Values[i] = ec._DeliveryAttempt_latency(ctx, field, obj)
+				if out.Values[i] == graphql.Null {
+					invalid = true
+				}
+				wg.Done()
+			}(i, field)
+		case "status_code":
+			wg.Add(1)
+			go func(i int, field graphql.CollectedField) {
+				out.Values[i] = ec._DeliveryAttempt_status_code(ctx, field, obj)
+				wg.Done()
+			}(i, field)
+		case "error":
+			wg.Add(1)
+			go func(i int, field graphql.CollectedField) {
+				out.Values[i] = ec._DeliveryAttempt_error(ctx, field, obj)
+				wg.Done()
+			}(i, field)
+		case "final":
+			out.Values[i] = ec._DeliveryAttempt_final(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalid = true
+			}
+		case "silenced_by":
+			wg.Add(1)
+			go func(i int, field graphql.CollectedField) {
+				out.Values[i] = ec._DeliveryAttempt_silenced_by(ctx, field, obj)
+				wg.Done()
+			}(i, field)
+		case "run":
+			wg.Add(1)
+			go func(i int, field graphql.CollectedField) {
+				out.Values[i] = ec._DeliveryAttempt_run(ctx, field, obj)
+				wg.Done()
+			}(i, field)
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	wg.Wait()
+	if invalid {
+		return graphql.Null
+	}
+	return out
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _DeliveryAttempt_delivery_id(ctx context.Context, field graphql.CollectedField, obj *db.DeliveryAttempt) graphql.Marshaler {
+	rctx := &graphql.ResolverContext{
+		Object: "DeliveryAttempt",
+		Args:   nil,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return ec.resolvers.DeliveryAttempt().DeliveryID(ctx, obj)
+	})
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	rctx.Result = res
+	return graphql.MarshalID(res)
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _DeliveryAttempt_notifier(ctx context.Context, field graphql.CollectedField, obj *db.DeliveryAttempt) graphql.Marshaler {
+	rctx := &graphql.ResolverContext{
+		Object: "DeliveryAttempt",
+		Args:   nil,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return obj.Notifier, nil
+	})
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	rctx.Result = res
+	return graphql.MarshalString(res)
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _DeliveryAttempt_attempt(ctx context.Context, field graphql.CollectedField, obj *db.DeliveryAttempt) graphql.Marshaler {
+	rctx := &graphql.ResolverContext{
+		Object: "DeliveryAttempt",
+		Args:   nil,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return obj.Attempt, nil
+	})
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(int)
+	rctx.Result = res
+	return graphql.MarshalInt(res)
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _DeliveryAttempt_at(ctx context.Context, field graphql.CollectedField, obj *db.DeliveryAttempt) graphql.Marshaler {
+	rctx := &graphql.ResolverContext{
+		Object: "DeliveryAttempt",
+		Args:   nil,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return obj.At, nil
+	})
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(time.Time)
+	rctx.Result = res
+	return graphql.MarshalTime(res)
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _DeliveryAttempt_latency(ctx context.Context, field graphql.CollectedField, obj *db.DeliveryAttempt) graphql.Marshaler {
+	rctx := &graphql.ResolverContext{
+		Object: "DeliveryAttempt",
+		Args:   nil,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return ec.resolvers.DeliveryAttempt().Latency(ctx, obj)
+	})
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(float64)
+	rctx.Result = res
+	return graphql.MarshalFloat(res)
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _DeliveryAttempt_status_code(ctx context.Context, field graphql.CollectedField, obj *db.DeliveryAttempt) graphql.Marshaler {
+	rctx := &graphql.ResolverContext{
+		Object: "DeliveryAttempt",
+		Args:   nil,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return ec.resolvers.DeliveryAttempt().StatusCode(ctx, obj)
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*int)
+	rctx.Result = res
+
+	if res == nil {
+		return graphql.Null
+	}
+	return graphql.MarshalInt(*res)
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _DeliveryAttempt_error(ctx context.Context, field graphql.CollectedField, obj *db.DeliveryAttempt) graphql.Marshaler {
+	rctx := &graphql.ResolverContext{
+		Object: "DeliveryAttempt",
+		Args:   nil,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return ec.resolvers.DeliveryAttempt().Error(ctx, obj)
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*string)
+	rctx.Result = res
+
+	if res == nil {
+		return graphql.Null
+	}
+	return graphql.MarshalString(*res)
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _DeliveryAttempt_final(ctx context.Context, field graphql.CollectedField, obj *db.DeliveryAttempt) graphql.Marshaler {
+	rctx := &graphql.ResolverContext{
+		Object: "DeliveryAttempt",
+		Args:   nil,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return obj.Final, nil
+	})
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(bool)
+	rctx.Result = res
+	return graphql.MarshalBoolean(res)
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _DeliveryAttempt_silenced_by(ctx context.Context, field graphql.CollectedField, obj *db.DeliveryAttempt) graphql.Marshaler {
+	rctx := &graphql.ResolverContext{
+		Object: "DeliveryAttempt",
+		Args:   nil,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return ec.resolvers.DeliveryAttempt().SilencedBy(ctx, obj)
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*string)
+	rctx.Result = res
+
+	if res == nil {
+		return graphql.Null
+	}
+	return graphql.MarshalString(*res)
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _DeliveryAttempt_run(ctx context.Context, field graphql.CollectedField, obj *db.DeliveryAttempt) graphql.Marshaler {
+	rctx := &graphql.ResolverContext{
+		Object: "DeliveryAttempt",
+		Args:   nil,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return ec.resolvers.DeliveryAttempt().Run(ctx, obj)
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*db.TestInstance)
+	rctx.Result = res
+
+	if res == nil {
+		return graphql.Null
+	}
+
+	return ec._TestInstance(ctx, field.Selections, res)
+}
+
+var labelImplementors = []string{"Label"}
+
+// nolint: gocyclo, errcheck, gas, goconst
+func (ec *executionContext) _Label(ctx context.Context, sel ast.SelectionSet, obj *Label) graphql.Marshaler {
+	fields := graphql.CollectFields(ctx, sel, labelImplementors)
+
+	out := graphql.NewOrderedMap(len(fields))
+	invalid := false
+	for i, field := range fields {
+		out.Keys[i] = field.Alias
+
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("Label")
+		case "name":
+			out.Values[i] = ec._Label_name(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalid = true
+			}
+		case "value":
+			out.Values[i] = ec._Label_value(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalid = true
+			}
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+
+	if invalid {
+		return graphql.Null
+	}
+	return out
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _Label_name(ctx context.Context, field graphql.CollectedField, obj *Label) graphql.Marshaler {
+	rctx := &graphql.ResolverContext{
+		Object: "Label",
+		Args:   nil,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return obj.Name, nil
+	})
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	rctx.Result = res
+	return graphql.MarshalString(res)
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _Label_value(ctx context.Context, field graphql.CollectedField, obj *Label) graphql.Marshaler {
+	rctx := &graphql.ResolverContext{
+		Object: "Label",
+		Args:   nil,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return obj.Value, nil
+	})
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	rctx.Result = res
+	return graphql.MarshalString(res)
+}
+
+var logEntryImplementors = []string{"LogEntry"}
+
+// nolint: gocyclo, errcheck, gas, goconst
+func (ec *executionContext) _LogEntry(ctx context.Context, sel ast.SelectionSet, obj *db.LogEntry) graphql.Marshaler {
+	fields := graphql.CollectFields(ctx, sel, logEntryImplementors)
+
+	var wg sync.WaitGroup
+	out := graphql.NewOrderedMap(len(fields))
+	invalid := false
+	for i, field := range fields {
+		out.Keys[i] = field.Alias
+
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("LogEntry")
+		case "time":
+			out.Values[i] = ec._LogEntry_time(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalid = true
+			}
+		case "level":
+			out.Values[i] = ec._LogEntry_level(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalid = true
+			}
+		case "message":
+			out.Values[i] = ec._LogEntry_message(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalid = true
+			}
+		case "fields":
+			wg.Add(1)
+			go func(i int, field graphql.CollectedField) {
+				out.Values[i] = ec._LogEntry_fields(ctx, field, obj)
+				if out.Values[i] == graphql.Null {
+					invalid = true
+				}
+				wg.Done()
+			}(i, field)
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	wg.Wait()
+	if invalid {
+		return graphql.Null
+	}
+	return out
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _LogEntry_time(ctx context.Context, field graphql.CollectedField, obj *db.LogEntry) graphql.Marshaler {
+	rctx := &graphql.ResolverContext{
+		Object: "LogEntry",
+		Args:   nil,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return obj.Time, nil
+	})
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(time.Time)
+	rctx.Result = res
+	return graphql.MarshalTime(res)
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _LogEntry_level(ctx context.Context, field graphql.CollectedField, obj *db.LogEntry) graphql.Marshaler {
+	rctx := &graphql.ResolverContext{
+		Object: "LogEntry",
+		Args:   nil,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return obj.Level, nil
+	})
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	rctx.Result = res
+	return graphql.MarshalString(res)
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _LogEntry_message(ctx context.Context, field graphql.CollectedField, obj *db.LogEntry) graphql.Marshaler {
+	rctx := &graphql.ResolverContext{
+		Object: "LogEntry",
+		Args:   nil,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return obj.Message, nil
+	})
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	rctx.Result = res
+	return graphql.MarshalString(res)
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _LogEntry_fields(ctx context.Context, field graphql.CollectedField, obj *db.LogEntry) graphql.Marshaler {
+	rctx := &graphql.ResolverContext{
+		Object: "LogEntry",
+		Args:   nil,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return ec.resolvers.LogEntry().Fields(ctx, obj)
+	})
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.([]LogField)
+	rctx.Result = res
+
+	arr1 := make(graphql.Array, len(res))
+	var wg sync.WaitGroup
+
+	isLen1 := len(res) == 1
+	if !isLen1 {
+		wg.Add(len(res))
+	}
+
+	for idx1 := range res {
+		idx1 := idx1
+		rctx := &graphql.ResolverContext{
+			Index:  &idx1,
+			Result: &res[idx1],
+		}
+		ctx := graphql.WithResolverContext(ctx, rctx)
+		f := func(idx1 int) {
+			if !isLen1 {
+				defer wg.Done()
+			}
+			arr1[idx1] = func() graphql.Marshaler {
+
+				return ec._LogField(ctx, field.Selections, &res[idx1])
+			}()
+		}
+		if isLen1 {
+			f(idx1)
+		} else {
+			go f(idx1)
+		}
+
+	}
+	wg.Wait()
+	return arr1
+}
+
+var logFieldImplementors = []string{"LogField"}
+
+// nolint: gocyclo, errcheck, gas, goconst
+func (ec *executionContext) _LogField(ctx context.Context, sel ast.SelectionSet, obj *LogField) graphql.Marshaler {
+	fields := graphql.CollectFields(ctx, sel, logFieldImplementors)
+
+	out := graphql.NewOrderedMap(len(fields))
+	invalid := false
+	for i, field := range fields {
+		out.Keys[i] = field.Alias
+
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("LogField")
+		case "key":
+			out.Values[i] = ec._LogField_key(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalid = true
+			}
+		case "value":
+			out.Values[i] = ec._LogField_value(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalid = true
+			}
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+
+	if invalid {
+		return graphql.Null
+	}
+	return out
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _LogField_key(ctx context.Context, field graphql.CollectedField, obj *LogField) graphql.Marshaler {
+	rctx := &graphql.ResolverContext{
+		Object: "LogField",
+		Args:   nil,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return obj.Key, nil
+	})
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	rctx.Result = res
+	return graphql.MarshalString(res)
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _LogField_value(ctx context.Context, field graphql.CollectedField, obj *LogField) graphql.Marshaler {
+	rctx := &graphql.ResolverContext{
+		Object: "LogField",
+		Args:   nil,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return obj.Value, nil
+	})
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	rctx.Result = res
+	return graphql.MarshalString(res)
+}
+
+var mutationImplementors = []string{"Mutation"}
+
+// nolint: gocyclo, errcheck, gas, goconst
+func (ec *executionContext) _Mutation(ctx context.Context, sel ast.SelectionSet) graphql.Marshaler {
+	fields := graphql.CollectFields(ctx, sel, mutationImplementors)
+
+	ctx = graphql.WithResolverContext(ctx, &graphql.ResolverContext{
+		Object: "Mutation",
+	})
+
+	out := graphql.NewOrderedMap(len(fields))
+	invalid := false
+	for i, field := range fields {
+		out.Keys[i] = field.Alias
+
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("Mutation")
+		case "pauseTest":
+			out.Values[i] = ec._Mutation_pauseTest(ctx, field)
+			if out.Values[i] == graphql.Null {
+				invalid = true
+			}
+		case "resumeTest":
+			out.Values[i] = ec._Mutation_resumeTest(ctx, field)
+			if out.Values[i] == graphql.Null {
+				invalid = true
+			}
+		case "createSilence":
+			out.Values[i] = ec._Mutation_createSilence(ctx, field)
+			if out.Values[i] == graphql.Null {
+				invalid = true
+			}
+		case "expireSilence":
+			out.Values[i] = ec._Mutation_expireSilence(ctx, field)
+			if out.Values[i] == graphql.Null {
+				invalid = true
+			}
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+
+	if invalid {
+		return graphql.Null
+	}
+	return out
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _Mutation_pauseTest(ctx context.Context, field graphql.CollectedField) graphql.Marshaler {
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := field_Mutation_pauseTest_args(rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	rctx := &graphql.ResolverContext{
+		Object: "Mutation",
+		Args:   args,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, nil, func(ctx context.Context) (interface{}, error) {
+		return ec.resolvers.Mutation().PauseTest(ctx, args["name"].(string), args["until"].(*time.Time), args["reason"].(string))
+	})
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(js.TestConfig)
+	rctx.Result = res
+
+	return ec._TestDefinition(ctx, field.Selections, &res)
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _Mutation_resumeTest(ctx context.Context, field graphql.CollectedField) graphql.Marshaler {
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := field_Mutation_resumeTest_args(rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	rctx := &graphql.ResolverContext{
+		Object: "Mutation",
+		Args:   args,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, nil, func(ctx context.Context) (interface{}, error) {
+		return ec.resolvers.Mutation().ResumeTest(ctx, args["name"].(string))
+	})
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(js.TestConfig)
+	rctx.Result = res
+
+	return ec._TestDefinition(ctx, field.Selections, &res)
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _Mutation_createSilence(ctx context.Context, field graphql.CollectedField) graphql.Marshaler {
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := field_Mutation_createSilence_args(rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	rctx := &graphql.ResolverContext{
+		Object: "Mutation",
+		Args:   args,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, nil, func(ctx context.Context) (interface{}, error) {
+		return ec.resolvers.Mutation().CreateSilence(ctx, args["tests"].(*string), args["labels"].([]LabelInput), args["start_at"].(*time.Time), args["end_at"].(time.Time), args["comment"].(string))
+	})
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(db.Silence)
+	rctx.Result = res
+
+	return ec._Silence(ctx, field.Selections, &res)
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _Mutation_expireSilence(ctx context.Context, field graphql.CollectedField) graphql.Marshaler {
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := field_Mutation_expireSilence_args(rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	rctx := &graphql.ResolverContext{
+		Object: "Mutation",
+		Args:   args,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, nil, func(ctx context.Context) (interface{}, error) {
+		return ec.resolvers.Mutation().ExpireSilence(ctx, args["id"].(string))
+	})
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(db.Silence)
+	rctx.Result = res
+
+	return ec._Silence(ctx, field.Selections, &res)
+}
+
+var pageInfoImplementors = []string{"PageInfo"}
+
+// nolint: gocyclo, errcheck, gas, goconst
+func (ec *executionContext) _PageInfo(ctx context.Context, sel ast.SelectionSet, obj *PageInfo) graphql.Marshaler {
+	fields := graphql.CollectFields(ctx, sel, pageInfoImplementors)
+
+	out := graphql.NewOrderedMap(len(fields))
+	invalid := false
+	for i, field := range fields {
+		out.Keys[i] = field.Alias
+
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("PageInfo")
+		case "hasNextPage":
+			out.Values[i] = ec._PageInfo_hasNextPage(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalid = true
+			}
+		case "hasPreviousPage":
+			out.Values[i] = ec._PageInfo_hasPreviousPage(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalid = true
+			}
+		case "startCursor":
+			out.Values[i] = ec._PageInfo_startCursor(ctx, field, obj)
+		case "endCursor":
+			out.Values[i] = ec._PageInfo_endCursor(ctx, field, obj)
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+
+	if invalid {
+		return graphql.Null
+	}
+	return out
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _PageInfo_hasNextPage(ctx context.Context, field graphql.CollectedField, obj *PageInfo) graphql.Marshaler {
+	rctx := &graphql.ResolverContext{
+		Object: "PageInfo",
+		Args:   nil,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return obj.HasNextPage, nil
+	})
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(bool)
+	rctx.Result = res
+	return graphql.MarshalBoolean(res)
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _PageInfo_hasPreviousPage(ctx context.Context, field graphql.CollectedField, obj *PageInfo) graphql.Marshaler {
+	rctx := &graphql.ResolverContext{
+		Object: "PageInfo",
+		Args:   nil,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return obj.HasPreviousPage, nil
+	})
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(bool)
+	rctx.Result = res
+	return graphql.MarshalBoolean(res)
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _PageInfo_startCursor(ctx context.Context, field graphql.CollectedField, obj *PageInfo) graphql.Marshaler {
+	rctx := &graphql.ResolverContext{
+		Object: "PageInfo",
+		Args:   nil,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return obj.StartCursor, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*string)
+	rctx.Result = res
+
+	if res == nil {
+		return graphql.Null
+	}
+	return graphql.MarshalString(*res)
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _PageInfo_endCursor(ctx context.Context, field graphql.CollectedField, obj *PageInfo) graphql.Marshaler {
+	rctx := &graphql.ResolverContext{
+		Object: "PageInfo",
+		Args:   nil,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return obj.EndCursor, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*string)
+	rctx.Result = res
+
+	if res == nil {
+		return graphql.Null
+	}
+	return graphql.MarshalString(*res)
+}
+
+var pauseImplementors = []string{"Pause"}
+
+// nolint: gocyclo, errcheck, gas, goconst
+func (ec *executionContext) _Pause(ctx context.Context, sel ast.SelectionSet, obj *db.Pause) graphql.Marshaler {
+	fields := graphql.CollectFields(ctx, sel, pauseImplementors)
+
+	var wg sync.WaitGroup
+	out := graphql.NewOrderedMap(len(fields))
+	invalid := false
+	for i, field := range fields {
+		out.Keys[i] = field.Alias
+
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("Pause")
+		case "until":
+			wg.Add(1)
+			go func(i int, field graphql.CollectedField) {
+				out.Values[i] = ec._Pause_until(ctx, field, obj)
+				wg.Done()
+			}(i, field)
+		case "reason":
+			out.Values[i] = ec._Pause_reason(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalid = true
+			}
+		case "paused_by":
+			wg.Add(1)
+			go func(i int, field graphql.CollectedField) {
+				out.Values[i] = ec._Pause_paused_by(ctx, field, obj)
+				if out.Values[i] == graphql.Null {
+					invalid = true
+				}
+				wg.Done()
+			}(i, field)
+		case "paused_at":
+			wg.Add(1)
+			go func(i int, field graphql.CollectedField) {
+				out.Values[i] = ec._Pause_paused_at(ctx, field, obj)
+				if out.Values[i] == graphql.Null {
+					invalid = true
+				}
+				wg.Done()
+			}(i, field)
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	wg.Wait()
+	if invalid {
+		return graphql.Null
+	}
+	return out
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _Pause_until(ctx context.Context, field graphql.CollectedField, obj *db.Pause) graphql.Marshaler {
+	rctx := &graphql.ResolverContext{
+		Object: "Pause",
+		Args:   nil,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return ec.resolvers.Pause().Until(ctx, obj)
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*time.Time)
+	rctx.Result = res
+
+	if res == nil {
+		return graphql.Null
+	}
+	return graphql.MarshalTime(*res)
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _Pause_reason(ctx context.Context, field graphql.CollectedField, obj *db.Pause) graphql.Marshaler {
+	rctx := &graphql.ResolverContext{
+		Object: "Pause",
+		Args:   nil,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return obj.Reason, nil
+	})
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	rctx.Result = res
+	return graphql.MarshalString(res)
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _Pause_paused_by(ctx context.Context, field graphql.CollectedField, obj *db.Pause) graphql.Marshaler {
+	rctx := &graphql.ResolverContext{
+		Object: "Pause",
+		Args:   nil,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return ec.resolvers.Pause().PausedBy(ctx, obj)
+	})
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	rctx.Result = res
+	return graphql.MarshalString(res)
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _Pause_paused_at(ctx context.Context, field graphql.CollectedField, obj *db.Pause) graphql.Marshaler {
+	rctx := &graphql.ResolverContext{
+		Object: "Pause",
+		Args:   nil,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return ec.resolvers.Pause().PausedAt(ctx, obj)
+	})
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(time.Time)
+	rctx.Result = res
+	return graphql.MarshalTime(res)
+}
+
+var queryImplementors = []string{"Query"}
+
+// nolint: gocyclo, errcheck, gas, goconst
+func (ec *executionContext) _Query(ctx context.Context, sel ast.SelectionSet) graphql.Marshaler {
+	fields := graphql.CollectFields(ctx, sel, queryImplementors)
+
+	ctx = graphql.WithResolverContext(ctx, &graphql.ResolverContext{
+		Object: "Query",
+	})
+
+	var wg sync.WaitGroup
+	out := graphql.NewOrderedMap(len(fields))
+	invalid := false
+	for i, field := range fields {
+		out.Keys[i] = field.Alias
+
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("Query")
+		case "runs":
+			wg.Add(1)
+			go func(i int, field graphql.CollectedField) {
+				out.Values[i] = ec._Query_runs(ctx, field)
+				if out.Values[i] == graphql.Null {
+					invalid = true
+				}
+				wg.Done()
+			}(i, field)
+		case "test":
+			wg.Add(1)
+			go func(i int, field graphql.CollectedField) {
+				out.Values[i] = ec._Query_test(ctx, field)
+				wg.Done()
+			}(i, field)
+		case "ongoingTests":
+			wg.Add(1)
+			go func(i int, field graphql.CollectedField) {
+				out.Values[i] = ec._Query_ongoingTests(ctx, field)
+				if out.Values[i] == graphql.Null {
+					invalid = true
+				}
+				wg.Done()
+			}(i, field)
+		case "definitions":
+			wg.Add(1)
+			go func(i int, field graphql.CollectedField) {
+				out.Values[i] = ec._Query_definitions(ctx, field)
+				if out.Values[i] == graphql.Null {
+					invalid = true
+				}
+				wg.Done()
+			}(i, field)
+		case "definition":
+			wg.Add(1)
+			go func(i int, field graphql.CollectedField) {
+				out.Values[i] = ec._Query_definition(ctx, field)
+				wg.Done()
+			}(i, field)
+		case "stats":
+			wg.Add(1)
+			go func(i int, field graphql.CollectedField) {
+				out.Values[i] = ec._Query_stats(ctx, field)
+				if out.Values[i] == graphql.Null {
+					invalid = true
+				}
+				wg.Done()
+			}(i, field)
+		case "slo":
+			wg.Add(1)
+			go func(i int, field graphql.CollectedField) {
+				out.Values[i] = ec._Query_slo(ctx, field)
+				wg.Done()
+			}(i, field)
+		case "alerts":
+			wg.Add(1)
+			go func(i int, field graphql.CollectedField) {
+				out.Values[i] = ec._Query_alerts(ctx, field)
+				if out.Values[i] == graphql.Null {
+					invalid = true
+				}
+				wg.Done()
+			}(i, field)
+		case "alertHistory":
+			wg.Add(1)
+			go func(i int, field graphql.CollectedField) {
+				out.Values[i] = ec._Query_alertHistory(ctx, field)
+				if out.Values[i] == graphql.Null {
+					invalid = true
+				}
+				wg.Done()
+			}(i, field)
+		case "silences":
+			wg.Add(1)
+			go func(i int, field graphql.CollectedField) {
+				out.Values[i] = ec._Query_silences(ctx, field)
+				if out.Values[i] == graphql.Null {
+					invalid = true
+				}
+				wg.Done()
+			}(i, field)
+		case "__type":
+			out.Values[i] = ec._Query___type(ctx, field)
+		case "__schema":
+			out.Values[i] = ec._Query___schema(ctx, field)
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	wg.Wait()
+	if invalid {
+		return graphql.Null
+	}
+	return out
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _Query_runs(ctx context.Context, field graphql.CollectedField) graphql.Marshaler {
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := field_Query_runs_args(rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	rctx := &graphql.ResolverContext{
+		Object: "Query",
+		Args:   args,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, nil, func(ctx context.Context) (interface{}, error) {
+		return ec.resolvers.Query().Runs(ctx, args["name"].(*string), args["pass"].(*bool), args["fail_kind"].(*FailureKind), args["from"].(*time.Time), args["to"].(*time.Time), args["first"].(int), args["after"].(*string))
+	})
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(TestInstanceConnection)
+	rctx.Result = res
+
+	return ec._TestInstanceConnection(ctx, field.Selections, &res)
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _Query_test(ctx context.Context, field graphql.CollectedField) graphql.Marshaler {
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := field_Query_test_args(rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	rctx := &graphql.ResolverContext{
+		Object: "Query",
+		Args:   args,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, nil, func(ctx context.Context) (interface{}, error) {
+		return ec.resolvers.Query().Test(ctx, args["id"].(string))
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*db.TestInstance)
+	rctx.Result = res
+
+	if res == nil {
+		return graphql.Null
+	}
+
+	return ec._TestInstance(ctx, field.Selections, res)
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _Query_ongoingTests(ctx context.Context, field graphql.CollectedField) graphql.Marshaler {
+	rctx := &graphql.ResolverContext{
+		Object: "Query",
+		Args:   nil,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, nil, func(ctx context.Context) (interface{}, error) {
+		return ec.resolvers.Query().OngoingTests(ctx)
+	})
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.([]db.TestInstance)
+	rctx.Result = res
+
+	arr1 := make(graphql.Array, len(res))
+	var wg sync.WaitGroup
+
+	isLen1 := len(res) == 1
+	if !isLen1 {
+		wg.Add(len(res))
+	}
+
+	for idx1 := range res {
+		idx1 := idx1
+		rctx := &graphql.ResolverContext{
+			Index:  &idx1,
+			Result: &res[idx1],
+		}
+		ctx := graphql.WithResolverContext(ctx, rctx)
+		f := func(idx1 int) {
+			if !isLen1 {
+				defer wg.Done()
+			}
+			arr1[idx1] = func() graphql.Marshaler {
+
+				return ec._TestInstance(ctx, field.Selections, &res[idx1])
+			}()
+		}
+		if isLen1 {
+			f(idx1)
+		} else {
+			go f(idx1)
+		}
+
+	}
+	wg.Wait()
+	return arr1
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _Query_definitions(ctx context.Context, field graphql.CollectedField) graphql.Marshaler {
+	rctx := &graphql.ResolverContext{
+		Object: "Query",
+		Args:   nil,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, nil, func(ctx context.Context) (interface{}, error) {
+		return ec.resolvers.Query().Definitions(ctx)
+	})
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.([]js.TestConfig)
+	rctx.Result = res
+
+	arr1 := make(graphql.Array, len(res))
+	var wg sync.WaitGroup
+
+	isLen1 := len(res) == 1
+	if !isLen1 {
+		wg.Add(len(res))
+	}
+
+	for idx1 := range res {
+		idx1 := idx1
+		rctx := &graphql.ResolverContext{
+			Index:  &idx1,
+			Result: &res[idx1],
+		}
+		ctx := graphql.WithResolverContext(ctx, rctx)
+		f := func(idx1 int) {
+			if !isLen1 {
+				defer wg.Done()
+			}
+			arr1[idx1] = func() graphql.Marshaler {
+
+				return ec._TestDefinition(ctx, field.Selections, &res[idx1])
+			}()
+		}
+		if isLen1 {
+			f(idx1)
+		} else {
+			go f(idx1)
+		}
+
+	}
+	wg.Wait()
+	return arr1
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _Query_definition(ctx context.Context, field graphql.CollectedField) graphql.Marshaler {
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := field_Query_definition_args(rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	rctx := &graphql.ResolverContext{
+		Object: "Query",
+		Args:   args,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, nil, func(ctx context.Context) (interface{}, error) {
+		return ec.resolvers.Query().Definition(ctx, args["name"].(string))
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*js.TestConfig)
+	rctx.Result = res
+
+	if res == nil {
+		return graphql.Null
+	}
+
+	return ec._TestDefinition(ctx, field.Selections, res)
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _Query_stats(ctx context.Context, field graphql.CollectedField) graphql.Marshaler {
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := field_Query_stats_args(rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	rctx := &graphql.ResolverContext{
+		Object: "Query",
+		Args:   args,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, nil, func(ctx context.Context) (interface{}, error) {
+		return ec.resolvers.Query().Stats(ctx, args["name"].(string), args["from"].(time.Time), args["to"].(time.Time), args["granularity"].(Granularity))
+	})
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.([]db.Rollup)
+	rctx.Result = res
+
+	arr1 := make(graphql.Array, len(res))
+	var wg sync.WaitGroup
+
+	isLen1 := len(res) == 1
+	if !isLen1 {
+		wg.Add(len(res))
+	}
+
+	for idx1 := range res {
+		idx1 := idx1
+		rctx := &graphql.ResolverContext{
+			Index:  &idx1,
+			Result: &res[idx1],
+		}
+		ctx := graphql.WithResolverContext(ctx, rctx)
+		f := func(idx1 int) {
+			if !isLen1 {
+				defer wg.Done()
+			}
+			arr1[idx1] = func() graphql.Marshaler {
+
+				return ec._Rollup(ctx, field.Selections, &res[idx1])
+			}()
+		}
+		if isLen1 {
+			f(idx1)
+		} else {
+			go f(idx1)
+		}
+
+	}
+	wg.Wait()
+	return arr1
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _Query_slo(ctx context.Context, field graphql.CollectedField) graphql.Marshaler {
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := field_Query_slo_args(rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	rctx := &graphql.ResolverContext{
+		Object: "Query",
+		Args:   args,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, nil, func(ctx context.Context) (interface{}, error) {
+		return ec.resolvers.Query().Slo(ctx, args["name"].(string))
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*slo.Report)
+	rctx.Result = res
+
+	if res == nil {
+		return graphql.Null
+	}
+
+	return ec._SLOReport(ctx, field.Selections, res)
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _Query_alerts(ctx context.Context, field graphql.CollectedField) graphql.Marshaler {
+	rctx := &graphql.ResolverContext{
+		Object: "Query",
+		Args:   nil,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, nil, func(ctx context.Context) (interface{}, error) {
+		return ec.resolvers.Query().Alerts(ctx)
+	})
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.([]db.AlertState)
+	rctx.Result = res
+
+	arr1 := make(graphql.Array, len(res))
+	var wg sync.WaitGroup
+
+	isLen1 := len(res) == 1
+	if !isLen1 {
+		wg.Add(len(res))
+	}
+
+	for idx1 := range res {
+		idx1 := idx1
+		rctx := &graphql.ResolverContext{
+			Index:  &idx1,
+			Result: &res[idx1],
+		}
+		ctx := graphql.WithResolverContext(ctx, rctx)
+		f := func(idx1 int) {
+			if !isLen1 {
+				defer wg.Done()
+			}
+			arr1[idx1] = func() graphql.Marshaler {
+
+				return ec._Alert(ctx, field.Selections, &res[idx1])
+			}()
+		}
+		if isLen1 {
+			f(idx1)
+		} else {
+			go f(idx1)
+		}
+
+	}
+	wg.Wait()
+	return arr1
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _Query_alertHistory(ctx context.Context, field graphql.CollectedField) graphql.Marshaler {
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := field_Query_alertHistory_args(rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	rctx := &graphql.ResolverContext{
+		Object: "Query",
+		Args:   args,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, nil, func(ctx context.Context) (interface{}, error) {
+		return ec.resolvers.Query().AlertHistory(ctx, args["name"].(string), args["from"].(*time.Time), args["to"].(*time.Time))
+	})
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(AlertHistory)
+	rctx.Result = res
+
+	return ec._AlertHistory(ctx, field.Selections, &res)
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _Query_silences(ctx context.Context, field graphql.CollectedField) graphql.Marshaler {
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := field_Query_silences_args(rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	rctx := &graphql.ResolverContext{
+		Object: "Query",
+		Args:   args,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, nil, func(ctx context.Context) (interface{}, error) {
+		return ec.resolvers.Query().Silences(ctx, args["active"].(*bool))
+	})
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.([]db.Silence)
+	rctx.Result = res
+
+	arr1 := make(graphql.Array, len(res))
+	var wg sync.WaitGroup
+
+	isLen1 := len(res) == 1
+	if !isLen1 {
+		wg.Add(len(res))
+	}
+
+	for idx1 := range res {
+		idx1 := idx1
+		rctx := &graphql.ResolverContext{
+			Index:  &idx1,
+			Result: &res[idx1],
+		}
+		ctx := graphql.WithResolverContext(ctx, rctx)
+		f := func(idx1 int) {
+			if !isLen1 {
+				defer wg.Done()
+			}
+			arr1[idx1] = func() graphql.Marshaler {
+
+				return ec._Silence(ctx, field.Selections, &res[idx1])
+			}()
+		}
+		if isLen1 {
+			f(idx1)
+		} else {
+			go f(idx1)
+		}
+
+	}
+	wg.Wait()
+	return arr1
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _Query___type(ctx context.Context, field graphql.CollectedField) graphql.Marshaler {
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := field_Query___type_args(rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	rctx := &graphql.ResolverContext{
+		Object: "Query",
+		Args:   args,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, nil, func(ctx context.Context) (interface{}, error) {
+		return ec.introspectType(args["name"].(string)), nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*introspection.Type)
+	rctx.Result = res
+
+	if res == nil {
+		return graphql.Null
+	}
+
+	return ec.___Type(ctx, field.Selections, res)
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _Query___schema(ctx context.Context, field graphql.CollectedField) graphql.Marshaler {
+	rctx := &graphql.ResolverContext{
+		Object: "Query",
+		Args:   nil,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, nil, func(ctx context.Context) (interface{}, error) {
+		return ec.introspectSchema(), nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*introspection.Schema)
+	rctx.Result = res
+
+	if res == nil {
+		return graphql.Null
+	}
+
+	return ec.___Schema(ctx, field.Selections, res)
+}
+
+var rollupImplementors = []string{"Rollup"}
+
+// nolint: gocyclo, errcheck, gas, goconst
+func (ec *executionContext) _Rollup(ctx context.Context, sel ast.SelectionSet, obj *db.Rollup) graphql.Marshaler {
+	fields := graphql.CollectFields(ctx, sel, rollupImplementors)
+
+	var wg sync.WaitGroup
+	out := graphql.NewOrderedMap(len(fields))
+	invalid := false
+	for i, field := range fields {
+		out.Keys[i] = field.Alias
+
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("Rollup")
+		case "name":
+			wg.Add(1)
+			go func(i int, field graphql.CollectedField) {
+				out.Values[i] = ec._Rollup_name(ctx, field, obj)
+				if out.Values[i] == graphql.Null {
+					invalid = true
+				}
+				wg.Done()
+			}(i, field)
+		case "granularity":
+			wg.Add(1)
+			go func(i int, field graphql.CollectedField) {
+				out.Values[i] = ec._Rollup_granularity(ctx, field, obj)
+				if out.Values[i] == graphql.Null {
+					invalid = true
+				}
+				wg.Done()
+			}(i, field)
+		case "start_at":
+			wg.Add(1)
+			go func(i int, field graphql.CollectedField) {
+				out.Values[i] = ec._Rollup_start_at(ctx, field, obj)
+				if out.Values[i] == graphql.Null {
+					invalid = true
+				}
+				wg.Done()
+			}(i, field)
+		case "runs":
+			out.Values[i] = ec._Rollup_runs(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalid = true
+			}
+		case "passes":
+			out.Values[i] = ec._Rollup_passes(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalid = true
+			}
+		case "failures":
+			out.Values[i] = ec._Rollup_failures(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalid = true
+			}
+		case "min_duration":
+			wg.Add(1)
+			go func(i int, field graphql.CollectedField) {
+				out.Values[i] = ec._Rollup_min_duration(ctx, field, obj)
+				if out.Values[i] == graphql.Null {
+					invalid = true
+				}
+				wg.Done()
+			}(i, field)
+		case "avg_duration":
+			wg.Add(1)
+			go func(i int, field graphql.CollectedField) {
+				out.Values[i] = ec._Rollup_avg_duration(ctx, field, obj)
+				if out.Values[i] == graphql.Null {
+					invalid = true
+				}
+				wg.Done()
+			}(i, field)
+		case "max_duration":
+			wg.Add(1)
+			go func(i int, field graphql.CollectedField) {
+				out.Values[i] = ec._Rollup_max_duration(ctx, field, obj)
+				if out.Values[i] == graphql.Null {
+					invalid = true
+				}
+				wg.Done()
+			}(i, field)
+		case "p95_duration":
+			wg.Add(1)
+			go func(i int, field graphql.CollectedField) {
+				out.Values[i] = ec._Rollup_p95_duration(ctx, field, obj)
+				if out.Values[i] == graphql.Null {
+					invalid = true
+				}
+				wg.Done()
+			}(i, field)
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	wg.Wait()
+	if invalid {
+		return graphql.Null
+	}
+	return out
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _Rollup_name(ctx context.Context, field graphql.CollectedField, obj *db.Rollup) graphql.Marshaler {
+	rctx := &graphql.ResolverContext{
+		Object: "Rollup",
+		Args:   nil,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return ec.resolvers.Rollup().Name(ctx, obj)
+	})
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	rctx.Result = res
+	return graphql.MarshalString(res)
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _Rollup_granularity(ctx context.Context, field graphql.CollectedField, obj *db.Rollup) graphql.Marshaler {
+	rctx := &graphql.ResolverContext{
+		Object: "Rollup",
+		Args:   nil,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return ec.resolvers.Rollup().Granularity(ctx, obj)
+	})
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(Granularity)
+	rctx.Result = res
+	return res
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _Rollup_start_at(ctx context.Context, field graphql.CollectedField, obj *db.Rollup) graphql.Marshaler {
+	rctx := &graphql.ResolverContext{
+		Object: "Rollup",
+		Args:   nil,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return ec.resolvers.Rollup().StartAt(ctx, obj)
+	})
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(time.Time)
+	rctx.Result = res
+	return graphql.MarshalTime(res)
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _Rollup_runs(ctx context.Context, field graphql.CollectedField, obj *db.Rollup) graphql.Marshaler {
+	rctx := &graphql.ResolverContext{
+		Object: "Rollup",
+		Args:   nil,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return obj.Runs, nil
+	})
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(int)
+	rctx.Result = res
+	return graphql.MarshalInt(res)
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _Rollup_passes(ctx context.Context, field graphql.CollectedField, obj *db.Rollup) graphql.Marshaler {
+	rctx := &graphql.ResolverContext{
+		Object: "Rollup",
+		Args:   nil,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return obj.Passes, nil
+	})
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(int)
+	rctx.Result = res
+	return graphql.MarshalInt(res)
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _Rollup_failures(ctx context.Context, field graphql.CollectedField, obj *db.Rollup) graphql.Marshaler {
+	rctx := &graphql.ResolverContext{
+		Object: "Rollup",
+		Args:   nil,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return obj.Failures, nil
+	})
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(int)
+	rctx.Result = res
+	return graphql.MarshalInt(res)
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _Rollup_min_duration(ctx context.Context, field graphql.CollectedField, obj *db.Rollup) graphql.Marshaler {
+	rctx := &graphql.ResolverContext{
+		Object: "Rollup",
+		Args:   nil,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return ec.resolvers.Rollup().MinDuration(ctx, obj)
+	})
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(float64)
+	rctx.Result = res
+	return graphql.MarshalFloat(res)
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _Rollup_avg_duration(ctx context.Context, field graphql.CollectedField, obj *db.Rollup) graphql.Marshaler {
+	rctx := &graphql.ResolverContext{
+		Object: "Rollup",
+		Args:   nil,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return ec.resolvers.Rollup().AvgDuration(ctx, obj)
+	})
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(float64)
+	rctx.Result = res
+	return graphql.MarshalFloat(res)
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _Rollup_max_duration(ctx context.Context, field graphql.CollectedField, obj *db.Rollup) graphql.Marshaler {
+	rctx := &graphql.ResolverContext{
+		Object: "Rollup",
+		Args:   nil,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return ec.resolvers.Rollup().MaxDuration(ctx, obj)
+	})
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(float64)
+	rctx.Result = res
+	return graphql.MarshalFloat(res)
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _Rollup_p95_duration(ctx context.Context, field graphql.CollectedField, obj *db.Rollup) graphql.Marshaler {
+	rctx := &graphql.ResolverContext{
+		Object: "Rollup",
+		Args:   nil,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return ec.resolvers.Rollup().P95Duration(ctx, obj)
+	})
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(float64)
+	rctx.Result = res
+	return graphql.MarshalFloat(res)
+}
+
+var sLOReportImplementors = []string{"SLOReport"}
+
+// nolint: gocyclo, errcheck, gas, goconst
+func (ec *executionContext) _SLOReport(ctx context.Context, sel ast.SelectionSet, obj *slo.Report) graphql.Marshaler {
+	fields := graphql.CollectFields(ctx, sel, sLOReportImplementors)
+
+	var wg sync.WaitGroup
+	out := graphql.NewOrderedMap(len(fields))
+	invalid := false
+	for i, field := range fields {
+		out.Keys[i] = field.Alias
+
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("SLOReport")
+		case "name":
+			out.Values[i] = ec._SLOReport_name(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalid = true
+			}
+		case "target":
+			wg.Add(1)
+			go func(i int, field graphql.CollectedField) {
+				out.Values[i] = ec._SLOReport_target(ctx, field, obj)
+				if out.Values[i] == graphql.Null {
+					invalid = true
+				}
+				wg.Done()
+			}(i, field)
+		case "window":
+			wg.Add(1)
+			go func(i int, field graphql.CollectedField) {
+				out.Values[i] = ec._SLOReport_window(ctx, field, obj)
+				if out.Values[i] == graphql.Null {
+					invalid = true
+				}
+				wg.Done()
+			}(i, field)
+		case "success_ratio":
+			wg.Add(1)
+			go func(i int, field graphql.CollectedField) {
+				out.Values[i] = ec._SLOReport_success_ratio(ctx, field, obj)
+				if out.Values[i] == graphql.Null {
+					invalid = true
+				}
+				wg.Done()
+			}(i, field)
+		case "error_budget_remaining":
+			wg.Add(1)
+			go func(i int, field graphql.CollectedField) {
+				out.Values[i] = ec._SLOReport_error_budget_remaining(ctx, field, obj)
+				if out.Values[i] == graphql.Null {
+					invalid = true
+				}
+				wg.Done()
+			}(i, field)
+		case "burn_rates":
+			wg.Add(1)
+			go func(i int, field graphql.CollectedField) {
+				out.Values[i] = ec._SLOReport_burn_rates(ctx, field, obj)
+				if out.Values[i] == graphql.Null {
+					invalid = true
+				}
+				wg.Done()
+			}(i, field)
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	wg.Wait()
+	if invalid {
+		return graphql.Null
+	}
+	return out
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _SLOReport_name(ctx context.Context, field graphql.CollectedField, obj *slo.Report) graphql.Marshaler {
+	rctx := &graphql.ResolverContext{
+		Object: "SLOReport",
+		Args:   nil,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return obj.Name, nil
+	})
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	rctx.Result = res
+	return graphql.MarshalString(res)
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _SLOReport_target(ctx context.Context, field graphql.CollectedField, obj *slo.Report) graphql.Marshaler {
+	rctx := &graphql.ResolverContext{
+		Object: "SLOReport",
+		Args:   nil,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return ec.resolvers.SLOReport().Target(ctx, obj)
+	})
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(float64)
+	rctx.Result = res
+	return graphql.MarshalFloat(res)
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _SLOReport_window(ctx context.Context, field graphql.CollectedField, obj *slo.Report) graphql.Marshaler {
+	rctx := &graphql.ResolverContext{
+		Object: "SLOReport",
+		Args:   nil,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return ec.resolvers.SLOReport().Window(ctx, obj)
+	})
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	rctx.Result = res
+	return graphql.MarshalString(res)
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _SLOReport_success_ratio(ctx context.Context, field graphql.CollectedField, obj *slo.Report) graphql.Marshaler {
+	rctx := &graphql.ResolverContext{
+		Object: "SLOReport",
+		Args:   nil,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return ec.resolvers.SLOReport().SuccessRatio(ctx, obj)
+	})
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(float64)
+	rctx.Result = res
+	return graphql.MarshalFloat(res)
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _SLOReport_error_budget_remaining(ctx context.Context, field graphql.CollectedField, obj *slo.Report) graphql.Marshaler {
+	rctx := &graphql.ResolverContext{
+		Object: "SLOReport",
+		Args:   nil,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return ec.resolvers.SLOReport().ErrorBudgetRemaining(ctx, obj)
+	})
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(float64)
+	rctx.Result = res
+	return graphql.MarshalFloat(res)
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _SLOReport_burn_rates(ctx context.Context, field graphql.CollectedField, obj *slo.Report) graphql.Marshaler {
+	rctx := &graphql.ResolverContext{
+		Object: "SLOReport",
+		Args:   nil,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return ec.resolvers.SLOReport().BurnRates(ctx, obj)
+	})
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.([]slo.BurnRate)
+	rctx.Result = res
+
+	arr1 := make(graphql.Array, len(res))
+	var wg sync.WaitGroup
+
+	isLen1 := len(res) == 1
+	if !isLen1 {
+		wg.Add(len(res))
+	}
+
+	for idx1 := range res {
+		idx1 := idx1
+		rctx := &graphql.ResolverContext{
+			Index:  &idx1,
+			Result: &res[idx1],
+		}
+		ctx := graphql.WithResolverContext(ctx, rctx)
+		f := func(idx1 int) {
+			if !isLen1 {
+				defer wg.Done()
+			}
+			arr1[idx1] = func() graphql.Marshaler {
+
+				return ec._BurnRate(ctx, field.Selections, &res[idx1])
+			}()
+		}
+		if isLen1 {
+			f(idx1)
+		} else {
+			go f(idx1)
+		}
+
+	}
+	wg.Wait()
+	return arr1
+}
+
+var silenceImplementors = []string{"Silence"}
+
+// nolint: gocyclo, errcheck, gas, goconst
+func (ec *executionContext) _Silence(ctx context.Context, sel ast.SelectionSet, obj *db.Silence) graphql.Marshaler {
+	fields := graphql.CollectFields(ctx, sel, silenceImplementors)
+
+	var wg sync.WaitGroup
+	out := graphql.NewOrderedMap(len(fields))
+	invalid := false
+	for i, field := range fields {
+		out.Keys[i] = field.Alias
+
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("Silence")
+		case "id":
+			out.Values[i] = ec._Silence_id(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalid = true
+			}
+		case "tests":
+			wg.Add(1)
+			go func(i int, field graphql.CollectedField) {
+				out.Values[i] = ec._Silence_tests(ctx, field, obj)
+				wg.Done()
+			}(i, field)
+		case "labels":
+			wg.Add(1)
+			go func(i int, field graphql.CollectedField) {
+				out.Values[i] = ec._Silence_labels(ctx, field, obj)
+				if out.Values[i] == graphql.Null {
+					invalid = true
+				}
+				wg.Done()
+			}(i, field)
+		case "start_at":
+			wg.Add(1)
+			go func(i int, field graphql.CollectedField) {
+				out.Values[i] = ec._Silence_start_at(ctx, field, obj)
+				if out.Values[i] == graphql.Null {
+					invalid = true
+				}
+				wg.Done()
+			}(i, field)
+		case "end_at":
+			wg.Add(1)
+			go func(i int, field graphql.CollectedField) {
+				out.Values[i] = ec._Silence_end_at(ctx, field, obj)
+				if out.Values[i] == graphql.Null {
+					invalid = true
+				}
+				wg.Done()
+			}(i, field)
+		case "comment":
+			out.Values[i] = ec._Silence_comment(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalid = true
+			}
+		case "created_by":
+			wg.Add(1)
+			go func(i int, field graphql.CollectedField) {
+				out.Values[i] = ec._Silence_created_by(ctx, field, obj)
+				if out.Values[i] == graphql.Null {
+					invalid = true
+				}
+				wg.Done()
+			}(i, field)
+		case "created_at":
+			wg.Add(1)
+			go func(i int, field graphql.CollectedField) {
+				out.Values[i] = ec._Silence_created_at(ctx, field, obj)
+				if out.Values[i] == graphql.Null {
+					invalid = true
+				}
+				wg.Done()
+			}(i, field)
+		case "active":
+			wg.Add(1)
+			go func(i int, field graphql.CollectedField) {
+				out.Values[i] = ec._Silence_active(ctx, field, obj)
+				if out.Values[i] == graphql.Null {
+					invalid = true
+				}
+				wg.Done()
+			}(i, field)
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	wg.Wait()
+	if invalid {
+		return graphql.Null
+	}
+	return out
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _Silence_id(ctx context.Context, field graphql.CollectedField, obj *db.Silence) graphql.Marshaler {
+	rctx := &graphql.ResolverContext{
+		Object: "Silence",
+		Args:   nil,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return obj.ID, nil
+	})
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	rctx.Result = res
+	return graphql.MarshalID(res)
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _Silence_tests(ctx context.Context, field graphql.CollectedField, obj *db.Silence) graphql.Marshaler {
+	rctx := &graphql.ResolverContext{
+		Object: "Silence",
+		Args:   nil,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return ec.resolvers.Silence().Tests(ctx, obj)
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*string)
+	rctx.Result = res
+
+	if res == nil {
+		return graphql.Null
+	}
+	return graphql.MarshalString(*res)
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _Silence_labels(ctx context.Context, field graphql.CollectedField, obj *db.Silence) graphql.Marshaler {
+	rctx := &graphql.ResolverContext{
+		Object: "Silence",
+		Args:   nil,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return ec.resolvers.Silence().Labels(ctx, obj)
+	})
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.([]Label)
+	rctx.Result = res
+
+	arr1 := make(graphql.Array, len(res))
+	var wg sync.WaitGroup
+
+	isLen1 := len(res) == 1
+	if !isLen1 {
+		wg.Add(len(res))
+	}
+
+	for idx1 := range res {
+		idx1 := idx1
+		rctx := &graphql.ResolverContext{
+			Index:  &idx1,
+			Result: &res[idx1],
+		}
+		ctx := graphql.WithResolverContext(ctx, rctx)
+		f := func(idx1 int) {
+			if !isLen1 {
+				defer wg.Done()
+			}
+			arr1[idx1] = func() graphql.Marshaler {
+
+				return ec._Label(ctx, field.Selections, &res[idx1])
+			}()
+		}
+		if isLen1 {
+			f(idx1)
+		} else {
+			go f(idx1)
+		}
+
+	}
+	wg.Wait()
+	return arr1
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _Silence_start_at(ctx context.Context, field graphql.CollectedField, obj *db.Silence) graphql.Marshaler {
+	rctx := &graphql.ResolverContext{
+		Object: "Silence",
+		Args:   nil,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return ec.resolvers.Silence().StartAt(ctx, obj)
+	})
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(time.Time)
+	rctx.Result = res
+	return graphql.MarshalTime(res)
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _Silence_end_at(ctx context.Context, field graphql.CollectedField, obj *db.Silence) graphql.Marshaler {
+	rctx := &graphql.ResolverContext{
+		Object: "Silence",
+		Args:   nil,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return ec.resolvers.Silence().EndAt(ctx, obj)
+	})
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(time.Time)
+	rctx.Result = res
+	return graphql.MarshalTime(res)
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _Silence_comment(ctx context.Context, field graphql.CollectedField, obj *db.Silence) graphql.Marshaler {
+	rctx := &graphql.ResolverContext{
+		Object: "Silence",
+		Args:   nil,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return obj.Comment, nil
+	})
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	rctx.Result = res
+	return graphql.MarshalString(res)
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _Silence_created_by(ctx context.Context, field graphql.CollectedField, obj *db.Silence) graphql.Marshaler {
+	rctx := &graphql.ResolverContext{
+		Object: "Silence",
+		Args:   nil,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return ec.resolvers.Silence().CreatedBy(ctx, obj)
+	})
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	rctx.Result = res
+	return graphql.MarshalString(res)
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _Silence_created_at(ctx context.Context, field graphql.CollectedField, obj *db.Silence) graphql.Marshaler {
+	rctx := &graphql.ResolverContext{
+		Object: "Silence",
+		Args:   nil,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return ec.resolvers.Silence().CreatedAt(ctx, obj)
+	})
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(time.Time)
+	rctx.Result = res
+	return graphql.MarshalTime(res)
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _Silence_active(ctx context.Context, field graphql.CollectedField, obj *db.Silence) graphql.Marshaler {
+	rctx := &graphql.ResolverContext{
+		Object: "Silence",
+		Args:   nil,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return ec.resolvers.Silence().Active(ctx, obj)
+	})
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(bool)
+	rctx.Result = res
+	return graphql.MarshalBoolean(res)
+}
+
+var subscriptionImplementors = []string{"Subscription"}
+
+// nolint: gocyclo, errcheck, gas, goconst
+func (ec *executionContext) _Subscription(ctx context.Context, sel ast.SelectionSet) func() graphql.Marshaler {
+	fields := graphql.CollectFields(ctx, sel, subscriptionImplementors)
+	ctx = graphql.WithResolverContext(ctx, &graphql.ResolverContext{
+		Object: "Subscription",
+	})
+	if len(fields) != 1 {
+		ec.Errorf(ctx, "must subscribe to exactly one stream")
+		return nil
+	}
+
+	switch fields[0].Name {
+	case "runStarted":
+		return ec._Subscription_runStarted(ctx, fields[0])
+	case "runFinished":
+		return ec._Subscription_runFinished(ctx, fields[0])
+	case "runLog":
+		return ec._Subscription_runLog(ctx, fields[0])
+	default:
+		panic("unknown field " + strconv.Quote(fields[0].Name))
+	}
+}
+
+func (ec *executionContext) _Subscription_runStarted(ctx context.Context, field graphql.CollectedField) func() graphql.Marshaler {
+	ctx = graphql.WithResolverContext(ctx, &graphql.ResolverContext{
+		Field: field,
+	})
+	results, err := ec.resolvers.Subscription().RunStarted(ctx)
+	if err != nil {
+		ec.Error(ctx, err)
+		return nil
+	}
+	return func() graphql.Marshaler {
+		res, ok := <-results
+		if !ok {
+			return nil
+		}
+		var out graphql.OrderedMap
+		out.Add(field.Alias, func() graphql.Marshaler {
+			return ec._TestInstance(ctx, field.Selections, &res)
+		}())
+		return &out
+	}
+}
+
+func (ec *executionContext) _Subscription_runFinished(ctx context.Context, field graphql.CollectedField) func() graphql.Marshaler {
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := field_Subscription_runFinished_args(rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return nil
+	}
+	ctx = graphql.WithResolverContext(ctx, &graphql.ResolverContext{
+		Field: field,
+	})
+	results, err := ec.resolvers.Subscription().RunFinished(ctx, args["name"].(*string))
+	if err != nil {
+		ec.Error(ctx, err)
+		return nil
+	}
+	return func() graphql.Marshaler {
+		res, ok := <-results
+		if !ok {
+			return nil
+		}
+		var out graphql.OrderedMap
+		out.Add(field.Alias, func() graphql.Marshaler {
+			return ec._TestInstance(ctx, field.Selections, &res)
+		}())
+		return &out
+	}
+}
+
+func (ec *executionContext) _Subscription_runLog(ctx context.Context, field graphql.CollectedField) func() graphql.Marshaler {
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := field_Subscription_runLog_args(rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return nil
+	}
+	ctx = graphql.WithResolverContext(ctx, &graphql.ResolverContext{
+		Field: field,
+	})
+	results, err := ec.resolvers.Subscription().RunLog(ctx, args["id"].(string))
+	if err != nil {
+		ec.Error(ctx, err)
+		return nil
+	}
+	return func() graphql.Marshaler {
+		res, ok := <-results
+		if !ok {
+			return nil
+		}
+		var out graphql.OrderedMap
+		out.Add(field.Alias, func() graphql.Marshaler {
+			return ec._LogEntry(ctx, field.Selections, &res)
+		}())
+		return &out
+	}
+}
+
+var testDefinitionImplementors = []string{"TestDefinition"}
+
+// nolint: gocyclo, errcheck, gas, goconst
+func (ec *executionContext) _TestDefinition(ctx context.Context, sel ast.SelectionSet, obj *js.TestConfig) graphql.Marshaler {
+	fields := graphql.CollectFields(ctx, sel, testDefinitionImplementors)
+
+	var wg sync.WaitGroup
+	out := graphql.NewOrderedMap(len(fields))
+	invalid := false
+	for i, field := range fields {
+		out.Keys[i] = field.Alias
+
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("TestDefinition")
+		case "name":
+			out.Values[i] = ec._TestDefinition_name(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalid = true
+			}
+		case "canary":
+			wg.Add(1)
+			go func(i int, field graphql.CollectedField) {
+				out.Values[i] = ec._TestDefinition_canary(ctx, field, obj)
+				if out.Values[i] == graphql.Null {
+					invalid = true
+				}
+				wg.Done()
+			}(i, field)
+		case "frequency":
+			wg.Add(1)
+			go func(i int, field graphql.CollectedField) {
+				out.Values[i] = ec._TestDefinition_frequency(ctx, field, obj)
+				if out.Values[i] == graphql.Null {
+					invalid = true
+				}
+				wg.Done()
+			}(i, field)
+		case "timeout":
+			wg.Add(1)
+			go func(i int, field graphql.CollectedField) {
+				out.Values[i] = ec._TestDefinition_timeout(ctx, field, obj)
+				if out.Values[i] == graphql.Null {
+					invalid = true
+				}
+				wg.Done()
+			}(i, field)
+		case "source":
+			out.Values[i] = ec._TestDefinition_source(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalid = true
+			}
+		case "labels":
+			wg.Add(1)
+			go func(i int, field graphql.CollectedField) {
+				out.Values[i] = ec._TestDefinition_labels(ctx, field, obj)
+				if out.Values[i] == graphql.Null {
+					invalid = true
+				}
+				wg.Done()
+			}(i, field)
+		case "slo":
+			wg.Add(1)
+			go func(i int, field graphql.CollectedField) {
+				out.Values[i] = ec._TestDefinition_slo(ctx, field, obj)
+				wg.Done()
+			}(i, field)
+		case "status":
+			wg.Add(1)
+			go func(i int, field graphql.CollectedField) {
+				out.Values[i] = ec._TestDefinition_status(ctx, field, obj)
+				if out.Values[i] == graphql.Null {
+					invalid = true
+				}
+				wg.Done()
+			}(i, field)
+		case "last_run":
+			wg.Add(1)
+			go func(i int, field graphql.CollectedField) {
+				out.Values[i] = ec._TestDefinition_last_run(ctx, field, obj)
+				wg.Done()
+			}(i, field)
+		case "last_success":
+			wg.Add(1)
+			go func(i int, field graphql.CollectedField) {
+				out.Values[i] = ec._TestDefinition_last_success(ctx, field, obj)
+				wg.Done()
+			}(i, field)
+		case "next_run_at":
+			wg.Add(1)
+			go func(i int, field graphql.CollectedField) {
+				out.Values[i] = ec._TestDefinition_next_run_at(ctx, field, obj)
+				wg.Done()
+			}(i, field)
+		case "pause":
+			wg.Add(1)
+			go func(i int, field graphql.CollectedField) {
+				out.Values[i] = ec._TestDefinition_pause(ctx, field, obj)
+				wg.Done()
+			}(i, field)
+		case "runs":
+			wg.Add(1)
+			go func(i int, field graphql.CollectedField) {
+				out.Values[i] = ec._TestDefinition_runs(ctx, field, obj)
+				if out.Values[i] == graphql.Null {
+					invalid = true
+				}
+				wg.Done()
+			}(i, field)
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	wg.Wait()
+	if invalid {
+		return graphql.Null
+	}
+	return out
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _TestDefinition_name(ctx context.Context, field graphql.CollectedField, obj *js.TestConfig) graphql.Marshaler {
+	rctx := &graphql.ResolverContext{
+		Object: "TestDefinition",
+		Args:   nil,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return obj.Name, nil
+	})
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	rctx.Result = res
+	return graphql.MarshalString(res)
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _TestDefinition_canary(ctx context.Context, field graphql.CollectedField, obj *js.TestConfig) graphql.Marshaler {
+	rctx := &graphql.ResolverContext{
+		Object: "TestDefinition",
+		Args:   nil,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return ec.resolvers.TestDefinition().Canary(ctx, obj)
+	})
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	rctx.Result = res
+	return graphql.MarshalString(res)
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _TestDefinition_frequency(ctx context.Context, field graphql.CollectedField, obj *js.TestConfig) graphql.Marshaler {
+	rctx := &graphql.ResolverContext{
+		Object: "TestDefinition",
+		Args:   nil,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return ec.resolvers.TestDefinition().Frequency(ctx, obj)
+	})
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(float64)
+	rctx.Result = res
+	return graphql.MarshalFloat(res)
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _TestDefinition_timeout(ctx context.Context, field graphql.CollectedField, obj *js.TestConfig) graphql.Marshaler {
+	rctx := &graphql.ResolverContext{
+		Object: "TestDefinition",
+		Args:   nil,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return ec.resolvers.TestDefinition().Timeout(ctx, obj)
+	})
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(float64)
+	rctx.Result = res
+	return graphql.MarshalFloat(res)
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _TestDefinition_source(ctx context.Context, field graphql.CollectedField, obj *js.TestConfig) graphql.Marshaler {
+	rctx := &graphql.ResolverContext{
+		Object: "TestDefinition",
+		Args:   nil,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return obj.Source, nil
+	})
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	rctx.Result = res
+	return graphql.MarshalString(res)
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _TestDefinition_labels(ctx context.Context, field graphql.CollectedField, obj *js.TestConfig) graphql.Marshaler {
+	rctx := &graphql.ResolverContext{
+		Object: "TestDefinition",
+		Args:   nil,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return ec.resolvers.TestDefinition().Labels(ctx, obj)
+	})
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.([]Label)
+	rctx.Result = res
+
+	arr1 := make(graphql.Array, len(res))
+	var wg sync.WaitGroup
+
+	isLen1 := len(res) == 1
+	if !isLen1 {
+		wg.Add(len(res))
+	}
+
+	for idx1 := range res {
+		idx1 := idx1
+		rctx := &graphql.ResolverContext{
+			Index:  &idx1,
+			Result: &res[idx1],
+		}
+		ctx := graphql.WithResolverContext(ctx, rctx)
+		f := func(idx1 int) {
+			if !isLen1 {
+				defer wg.Done()
+			}
+			arr1[idx1] = func() graphql.Marshaler {
+
+				return ec._Label(ctx, field.Selections, &res[idx1])
+			}()
+		}
+		if isLen1 {
+			f(idx1)
+		} else {
+			go f(idx1)
+		}
+
+	}
+	wg.Wait()
+	return arr1
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _TestDefinition_slo(ctx context.Context, field graphql.CollectedField, obj *js.TestConfig) graphql.Marshaler {
+	rctx := &graphql.ResolverContext{
+		Object: "TestDefinition",
+		Args:   nil,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return ec.resolvers.TestDefinition().Slo(ctx, obj)
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*slo.Report)
+	rctx.Result = res
+
+	if res == nil {
+		return graphql.Null
+	}
+
+	return ec._SLOReport(ctx, field.Selections, res)
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _TestDefinition_status(ctx context.Context, field graphql.CollectedField, obj *js.TestConfig) graphql.Marshaler {
+	rctx := &graphql.ResolverContext{
+		Object: "TestDefinition",
+		Args:   nil,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return ec.resolvers.TestDefinition().Status(ctx, obj)
+	})
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(TestStatus)
+	rctx.Result = res
+	return res
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _TestDefinition_last_run(ctx context.Context, field graphql.CollectedField, obj *js.TestConfig) graphql.Marshaler {
+	rctx := &graphql.ResolverContext{
+		Object: "TestDefinition",
+		Args:   nil,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return ec.resolvers.TestDefinition().LastRun(ctx, obj)
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*db.TestInstance)
+	rctx.Result = res
+
+	if res == nil {
+		return graphql.Null
+	}
+
+	return ec._TestInstance(ctx, field.Selections, res)
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _TestDefinition_last_success(ctx context.Context, field graphql.CollectedField, obj *js.TestConfig) graphql.Marshaler {
+	rctx := &graphql.ResolverContext{
+		Object: "TestDefinition",
+		Args:   nil,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return ec.resolvers.TestDefinition().LastSuccess(ctx, obj)
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*db.TestInstance)
+	rctx.Result = res
+
+	if res == nil {
+		return graphql.Null
+	}
+
+	return ec._TestInstance(ctx, field.Selections, res)
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _TestDefinition_next_run_at(ctx context.Context, field graphql.CollectedField, obj *js.TestConfig) graphql.Marshaler {
+	rctx := &graphql.ResolverContext{
+		Object: "TestDefinition",
+		Args:   nil,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return ec.resolvers.TestDefinition().NextRunAt(ctx, obj)
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*time.Time)
+	rctx.Result = res
 
+	if res == nil {
+		return graphql.Null
 	}
-	return 0, false
+	return graphql.MarshalTime(*res)
 }
 
-func (e *executableSchema) Query(ctx context.Context, op *ast.OperationDefinition) *graphql.Response {
-	ec := executionContext{graphql.GetRequestContext(ctx), e}
-
-	buf := ec.RequestMiddleware(ctx, func(ctx context.Context) []byte {
-		data := ec._Query(ctx, op.SelectionSet)
-		var buf bytes.Buffer
-		data.MarshalGQL(&buf)
-		return buf.Bytes()
+// nolint: vetshadow
+func (ec *executionContext) _TestDefinition_pause(ctx context.Context, field graphql.CollectedField, obj *js.TestConfig) graphql.Marshaler {
+	rctx := &graphql.ResolverContext{
+		Object: "TestDefinition",
+		Args:   nil,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return ec.resolvers.TestDefinition().Pause(ctx, obj)
 	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*db.Pause)
+	rctx.Result = res
 
-	return &graphql.Response{
-		Data:   buf,
-		Errors: ec.Errors,
+	if res == nil {
+		return graphql.Null
 	}
-}
 
-func (e *executableSchema) Mutation(ctx context.Context, op *ast.OperationDefinition) *graphql.Response {
-	return graphql.ErrorResponse(ctx, "mutations are not supported")
+	return ec._Pause(ctx, field.Selections, res)
 }
 
-func (e *executableSchema) Subscription(ctx context.Context, op *ast.OperationDefinition) func() *graphql.Response {
-	return graphql.OneShot(graphql.ErrorResponse(ctx, "subscriptions are not supported"))
-}
+// nolint: vetshadow
+func (ec *executionContext) _TestDefinition_runs(ctx context.Context, field graphql.CollectedField, obj *js.TestConfig) graphql.Marshaler {
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := field_TestDefinition_runs_args(rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	rctx := &graphql.ResolverContext{
+		Object: "TestDefinition",
+		Args:   args,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return ec.resolvers.TestDefinition().Runs(ctx, obj, args["pass"].(*bool), args["fail_kind"].(*FailureKind), args["from"].(*time.Time), args["to"].(*time.Time), args["first"].(int), args["after"].(*string))
+	})
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(TestInstanceConnection)
+	rctx.Result = res
 
-type executionContext struct {
-	*graphql.RequestContext
-	*executableSchema
+	return ec._TestInstanceConnection(ctx, field.Selections, &res)
 }
 
-var queryImplementors = []string{"Query"}
+var testInstanceImplementors = []string{"TestInstance"}
 
 // nolint: gocyclo, errcheck, gas, goconst
-func (ec *executionContext) _Query(ctx context.Context, sel ast.SelectionSet) graphql.Marshaler {
-	fields := graphql.CollectFields(ctx, sel, queryImplementors)
-
-	ctx = graphql.WithResolverContext(ctx, &graphql.ResolverContext{
-		Object: "Query",
-	})
+func (ec *executionContext) _TestInstance(ctx context.Context, sel ast.SelectionSet, obj *db.TestInstance) graphql.Marshaler {
+	fields := graphql.CollectFields(ctx, sel, testInstanceImplementors)
 
 	var wg sync.WaitGroup
 	out := graphql.NewOrderedMap(len(fields))
@@ -262,35 +5735,54 @@ func (ec *executionContext) _Query(ctx context.Context, sel ast.SelectionSet) gr
 
 		switch field.Name {
 		case "__typename":
-			out.Values[i] = graphql.MarshalString("Query")
-		case "tests":
+			out.Values[i] = graphql.MarshalString("TestInstance")
+		case "id":
 			wg.Add(1)
 			go func(i int, field graphql.CollectedField) {
-				out.Values[i] = ec._Query_tests(ctx, field)
+				out.Values[i] = ec._TestInstance_id(ctx, field, obj)
 				if out.Values[i] == graphql.Null {
 					invalid = true
 				}
 				wg.Done()
 			}(i, field)
-		case "test":
+		case "name":
 			wg.Add(1)
 			go func(i int, field graphql.CollectedField) {
-				out.Values[i] = ec._Query_test(ctx, field)
+				out.Values[i] = ec._TestInstance_name(ctx, field, obj)
+				if out.Values[i] == graphql.Null {
+					invalid = true
+				}
 				wg.Done()
 			}(i, field)
-		case "ongoingTests":
+		case "start_at":
 			wg.Add(1)
 			go func(i int, field graphql.CollectedField) {
-				out.Values[i] = ec._Query_ongoingTests(ctx, field)
+				out.Values[i] = ec._TestInstance_start_at(ctx, field, obj)
 				if out.Values[i] == graphql.Null {
 					invalid = true
 				}
 				wg.Done()
 			}(i, field)
-		case "__type":
-			out.Values[i] = ec._Query___type(ctx, field)
-		case "__schema":
-			out.Values[i] = ec._Query___schema(ctx, field)
+		case "end_at":
+			wg.Add(1)
+			go func(i int, field graphql.CollectedField) {
+				out.Values[i] = ec._TestInstance_end_at(ctx, field, obj)
+				wg.Done()
+			}(i, field)
+		case "pass":
+			out.Values[i] = ec._TestInstance_pass(ctx, field, obj)
+		case "fail_cause":
+			wg.Add(1)
+			go func(i int, field graphql.CollectedField) {
+				out.Values[i] = ec._TestInstance_fail_cause(ctx, field, obj)
+				wg.Done()
+			}(i, field)
+		case "fail_kind":
+			wg.Add(1)
+			go func(i int, field graphql.CollectedField) {
+				out.Values[i] = ec._TestInstance_fail_kind(ctx, field, obj)
+				wg.Done()
+			}(i, field)
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -303,15 +5795,15 @@ func (ec *executionContext) _Query(ctx context.Context, sel ast.SelectionSet) gr
 }
 
 // nolint: vetshadow
-func (ec *executionContext) _Query_tests(ctx context.Context, field graphql.CollectedField) graphql.Marshaler {
+func (ec *executionContext) _TestInstance_id(ctx context.Context, field graphql.CollectedField, obj *db.TestInstance) graphql.Marshaler {
 	rctx := &graphql.ResolverContext{
-		Object: "Query",
+		Object: "TestInstance",
 		Args:   nil,
 		Field:  field,
 	}
 	ctx = graphql.WithResolverContext(ctx, rctx)
-	resTmp := ec.FieldMiddleware(ctx, nil, func(ctx context.Context) (interface{}, error) {
-		return ec.resolvers.Query().Tests(ctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return ec.resolvers.TestInstance().ID(ctx, obj)
 	})
 	if resTmp == nil {
 		if !ec.HasError(rctx) {
@@ -319,84 +5811,43 @@ func (ec *executionContext) _Query_tests(ctx context.Context, field graphql.Coll
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]db.TestInstance)
+	res := resTmp.(string)
 	rctx.Result = res
-
-	arr1 := make(graphql.Array, len(res))
-	var wg sync.WaitGroup
-
-	isLen1 := len(res) == 1
-	if !isLen1 {
-		wg.Add(len(res))
-	}
-
-	for idx1 := range res {
-		idx1 := idx1
-		rctx := &graphql.ResolverContext{
-			Index:  &idx1,
-			Result: &res[idx1],
-		}
-		ctx := graphql.WithResolverContext(ctx, rctx)
-		f := func(idx1 int) {
-			if !isLen1 {
-				defer wg.Done()
-			}
-			arr1[idx1] = func() graphql.Marshaler {
-
-				return ec._TestInstance(ctx, field.Selections, &res[idx1])
-			}()
-		}
-		if isLen1 {
-			f(idx1)
-		} else {
-			go f(idx1)
-		}
-
-	}
-	wg.Wait()
-	return arr1
+	return graphql.MarshalID(res)
 }
 
 // nolint: vetshadow
-func (ec *executionContext) _Query_test(ctx context.Context, field graphql.CollectedField) graphql.Marshaler {
-	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := field_Query_test_args(rawArgs)
-	if err != nil {
-		ec.Error(ctx, err)
-		return graphql.Null
-	}
+func (ec *executionContext) _TestInstance_name(ctx context.Context, field graphql.CollectedField, obj *db.TestInstance) graphql.Marshaler {
 	rctx := &graphql.ResolverContext{
-		Object: "Query",
-		Args:   args,
+		Object: "TestInstance",
+		Args:   nil,
 		Field:  field,
 	}
 	ctx = graphql.WithResolverContext(ctx, rctx)
-	resTmp := ec.FieldMiddleware(ctx, nil, func(ctx context.Context) (interface{}, error) {
-		return ec.resolvers.Query().Test(ctx, args["id"].(string))
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return ec.resolvers.TestInstance().Name(ctx, obj)
 	})
 	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*db.TestInstance)
+	res := resTmp.(string)
 	rctx.Result = res
-
-	if res == nil {
-		return graphql.Null
-	}
-
-	return ec._TestInstance(ctx, field.Selections, res)
+	return graphql.MarshalString(res)
 }
 
 // nolint: vetshadow
-func (ec *executionContext) _Query_ongoingTests(ctx context.Context, field graphql.CollectedField) graphql.Marshaler {
+func (ec *executionContext) _TestInstance_start_at(ctx context.Context, field graphql.CollectedField, obj *db.TestInstance) graphql.Marshaler {
 	rctx := &graphql.ResolverContext{
-		Object: "Query",
+		Object: "TestInstance",
 		Args:   nil,
 		Field:  field,
 	}
 	ctx = graphql.WithResolverContext(ctx, rctx)
-	resTmp := ec.FieldMiddleware(ctx, nil, func(ctx context.Context) (interface{}, error) {
-		return ec.resolvers.Query().OngoingTests(ctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return ec.resolvers.TestInstance().StartAt(ctx, obj)
 	})
 	if resTmp == nil {
 		if !ec.HasError(rctx) {
@@ -404,105 +5855,105 @@ func (ec *executionContext) _Query_ongoingTests(ctx context.Context, field graph
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]db.TestInstance)
+	res := resTmp.(time.Time)
 	rctx.Result = res
+	return graphql.MarshalTime(res)
+}
 
-	arr1 := make(graphql.Array, len(res))
-	var wg sync.WaitGroup
-
-	isLen1 := len(res) == 1
-	if !isLen1 {
-		wg.Add(len(res))
+// nolint: vetshadow
+func (ec *executionContext) _TestInstance_end_at(ctx context.Context, field graphql.CollectedField, obj *db.TestInstance) graphql.Marshaler {
+	rctx := &graphql.ResolverContext{
+		Object: "TestInstance",
+		Args:   nil,
+		Field:  field,
 	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return ec.resolvers.TestInstance().EndAt(ctx, obj)
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*time.Time)
+	rctx.Result = res
 
-	for idx1 := range res {
-		idx1 := idx1
-		rctx := &graphql.ResolverContext{
-			Index:  &idx1,
-			Result: &res[idx1],
-		}
-		ctx := graphql.WithResolverContext(ctx, rctx)
-		f := func(idx1 int) {
-			if !isLen1 {
-				defer wg.Done()
-			}
-			arr1[idx1] = func() graphql.Marshaler {
-
-				return ec._TestInstance(ctx, field.Selections, &res[idx1])
-			}()
-		}
-		if isLen1 {
-			f(idx1)
-		} else {
-			go f(idx1)
-		}
-
+	if res == nil {
+		return graphql.Null
 	}
-	wg.Wait()
-	return arr1
+	return graphql.MarshalTime(*res)
 }
 
 // nolint: vetshadow
-func (ec *executionContext) _Query___type(ctx context.Context, field graphql.CollectedField) graphql.Marshaler {
-	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := field_Query___type_args(rawArgs)
-	if err != nil {
-		ec.Error(ctx, err)
+func (ec *executionContext) _TestInstance_pass(ctx context.Context, field graphql.CollectedField, obj *db.TestInstance) graphql.Marshaler {
+	rctx := &graphql.ResolverContext{
+		Object: "TestInstance",
+		Args:   nil,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return obj.Pass, nil
+	})
+	if resTmp == nil {
 		return graphql.Null
 	}
+	res := resTmp.(bool)
+	rctx.Result = res
+	return graphql.MarshalBoolean(res)
+}
+
+// nolint: vetshadow
+func (ec *executionContext) _TestInstance_fail_cause(ctx context.Context, field graphql.CollectedField, obj *db.TestInstance) graphql.Marshaler {
 	rctx := &graphql.ResolverContext{
-		Object: "Query",
-		Args:   args,
+		Object: "TestInstance",
+		Args:   nil,
 		Field:  field,
 	}
 	ctx = graphql.WithResolverContext(ctx, rctx)
-	resTmp := ec.FieldMiddleware(ctx, nil, func(ctx context.Context) (interface{}, error) {
-		return ec.introspectType(args["name"].(string)), nil
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return ec.resolvers.TestInstance().FailCause(ctx, obj)
 	})
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*introspection.Type)
+	res := resTmp.(*string)
 	rctx.Result = res
 
 	if res == nil {
 		return graphql.Null
 	}
-
-	return ec.___Type(ctx, field.Selections, res)
+	return graphql.MarshalString(*res)
 }
 
 // nolint: vetshadow
-func (ec *executionContext) _Query___schema(ctx context.Context, field graphql.CollectedField) graphql.Marshaler {
+func (ec *executionContext) _TestInstance_fail_kind(ctx context.Context, field graphql.CollectedField, obj *db.TestInstance) graphql.Marshaler {
 	rctx := &graphql.ResolverContext{
-		Object: "Query",
+		Object: "TestInstance",
 		Args:   nil,
 		Field:  field,
 	}
 	ctx = graphql.WithResolverContext(ctx, rctx)
-	resTmp := ec.FieldMiddleware(ctx, nil, func(ctx context.Context) (interface{}, error) {
-		return ec.introspectSchema(), nil
+	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
+		return ec.resolvers.TestInstance().FailKind(ctx, obj)
 	})
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*introspection.Schema)
+	res := resTmp.(*FailureKind)
 	rctx.Result = res
 
 	if res == nil {
 		return graphql.Null
 	}
-
-	return ec.___Schema(ctx, field.Selections, res)
+	return *res
 }
 
-var testInstanceImplementors = []string{"TestInstance"}
+var testInstanceConnectionImplementors = []string{"TestInstanceConnection"}
 
 // nolint: gocyclo, errcheck, gas, goconst
-func (ec *executionContext) _TestInstance(ctx context.Context, sel ast.SelectionSet, obj *db.TestInstance) graphql.Marshaler {
-	fields := graphql.CollectFields(ctx, sel, testInstanceImplementors)
+func (ec *executionContext) _TestInstanceConnection(ctx context.Context, sel ast.SelectionSet, obj *TestInstanceConnection) graphql.Marshaler {
+	fields := graphql.CollectFields(ctx, sel, testInstanceConnectionImplementors)
 
-	var wg sync.WaitGroup
 	out := graphql.NewOrderedMap(len(fields))
 	invalid := false
 	for i, field := range fields {
@@ -510,53 +5961,27 @@ func (ec *executionContext) _TestInstance(ctx context.Context, sel ast.Selection
 
 		switch field.Name {
 		case "__typename":
-			out.Values[i] = graphql.MarshalString("TestInstance")
-		case "id":
-			wg.Add(1)
-			go func(i int, field graphql.CollectedField) {
-				out.Values[i] = ec._TestInstance_id(ctx, field, obj)
-				if out.Values[i] == graphql.Null {
-					invalid = true
-				}
-				wg.Done()
-			}(i, field)
-		case "name":
-			wg.Add(1)
-			go func(i int, field graphql.CollectedField) {
-				out.Values[i] = ec._TestInstance_name(ctx, field, obj)
-				if out.Values[i] == graphql.Null {
-					invalid = true
-				}
-				wg.Done()
-			}(i, field)
-		case "start_at":
-			wg.Add(1)
-			go func(i int, field graphql.CollectedField) {
-				out.Values[i] = ec._TestInstance_start_at(ctx, field, obj)
-				if out.Values[i] == graphql.Null {
-					invalid = true
-				}
-				wg.Done()
-			}(i, field)
-		case "end_at":
-			wg.Add(1)
-			go func(i int, field graphql.CollectedField) {
-				out.Values[i] = ec._TestInstance_end_at(ctx, field, obj)
-				wg.Done()
-			}(i, field)
-		case "pass":
-			out.Values[i] = ec._TestInstance_pass(ctx, field, obj)
-		case "fail_cause":
-			wg.Add(1)
-			go func(i int, field graphql.CollectedField) {
-				out.Values[i] = ec._TestInstance_fail_cause(ctx, field, obj)
-				wg.Done()
-			}(i, field)
+			out.Values[i] = graphql.MarshalString("TestInstanceConnection")
+		case "edges":
+			out.Values[i] = ec._TestInstanceConnection_edges(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalid = true
+			}
+		case "pageInfo":
+			out.Values[i] = ec._TestInstanceConnection_pageInfo(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalid = true
+			}
+		case "totalCount":
+			out.Values[i] = ec._TestInstanceConnection_totalCount(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalid = true
+			}
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
 	}
-	wg.Wait()
+
 	if invalid {
 		return graphql.Null
 	}
@@ -564,15 +5989,15 @@ func (ec *executionContext) _TestInstance(ctx context.Context, sel ast.Selection
 }
 
 // nolint: vetshadow
-func (ec *executionContext) _TestInstance_id(ctx context.Context, field graphql.CollectedField, obj *db.TestInstance) graphql.Marshaler {
+func (ec *executionContext) _TestInstanceConnection_edges(ctx context.Context, field graphql.CollectedField, obj *TestInstanceConnection) graphql.Marshaler {
 	rctx := &graphql.ResolverContext{
-		Object: "TestInstance",
+		Object: "TestInstanceConnection",
 		Args:   nil,
 		Field:  field,
 	}
 	ctx = graphql.WithResolverContext(ctx, rctx)
 	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
-		return ec.resolvers.TestInstance().ID(ctx, obj)
+		return obj.Edges, nil
 	})
 	if resTmp == nil {
 		if !ec.HasError(rctx) {
@@ -580,21 +6005,54 @@ func (ec *executionContext) _TestInstance_id(ctx context.Context, field graphql.
 		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.([]TestInstanceEdge)
 	rctx.Result = res
-	return graphql.MarshalID(res)
+
+	arr1 := make(graphql.Array, len(res))
+	var wg sync.WaitGroup
+
+	isLen1 := len(res) == 1
+	if !isLen1 {
+		wg.Add(len(res))
+	}
+
+	for idx1 := range res {
+		idx1 := idx1
+		rctx := &graphql.ResolverContext{
+			Index:  &idx1,
+			Result: &res[idx1],
+		}
+		ctx := graphql.WithResolverContext(ctx, rctx)
+		f := func(idx1 int) {
+			if !isLen1 {
+				defer wg.Done()
+			}
+			arr1[idx1] = func() graphql.Marshaler {
+
+				return ec._TestInstanceEdge(ctx, field.Selections, &res[idx1])
+			}()
+		}
+		if isLen1 {
+			f(idx1)
+		} else {
+			go f(idx1)
+		}
+
+	}
+	wg.Wait()
+	return arr1
 }
 
 // nolint: vetshadow
-func (ec *executionContext) _TestInstance_name(ctx context.Context, field graphql.CollectedField, obj *db.TestInstance) graphql.Marshaler {
+func (ec *executionContext) _TestInstanceConnection_pageInfo(ctx context.Context, field graphql.CollectedField, obj *TestInstanceConnection) graphql.Marshaler {
 	rctx := &graphql.ResolverContext{
-		Object: "TestInstance",
+		Object: "TestInstanceConnection",
 		Args:   nil,
 		Field:  field,
 	}
 	ctx = graphql.WithResolverContext(ctx, rctx)
 	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
-		return ec.resolvers.TestInstance().Name(ctx, obj)
+		return obj.PageInfo, nil
 	})
 	if resTmp == nil {
 		if !ec.HasError(rctx) {
@@ -602,21 +6060,22 @@ func (ec *executionContext) _TestInstance_name(ctx context.Context, field graphq
 		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(PageInfo)
 	rctx.Result = res
-	return graphql.MarshalString(res)
+
+	return ec._PageInfo(ctx, field.Selections, &res)
 }
 
 // nolint: vetshadow
-func (ec *executionContext) _TestInstance_start_at(ctx context.Context, field graphql.CollectedField, obj *db.TestInstance) graphql.Marshaler {
+func (ec *executionContext) _TestInstanceConnection_totalCount(ctx context.Context, field graphql.CollectedField, obj *TestInstanceConnection) graphql.Marshaler {
 	rctx := &graphql.ResolverContext{
-		Object: "TestInstance",
+		Object: "TestInstanceConnection",
 		Args:   nil,
 		Field:  field,
 	}
 	ctx = graphql.WithResolverContext(ctx, rctx)
 	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
-		return ec.resolvers.TestInstance().StartAt(ctx, obj)
+		return obj.TotalCount, nil
 	})
 	if resTmp == nil {
 		if !ec.HasError(rctx) {
@@ -624,74 +6083,89 @@ func (ec *executionContext) _TestInstance_start_at(ctx context.Context, field gr
 		}
 		return graphql.Null
 	}
-	res := resTmp.(time.Time)
+	res := resTmp.(int)
 	rctx.Result = res
-	return graphql.MarshalTime(res)
+	return graphql.MarshalInt(res)
 }
 
-// nolint: vetshadow
-func (ec *executionContext) _TestInstance_end_at(ctx context.Context, field graphql.CollectedField, obj *db.TestInstance) graphql.Marshaler {
-	rctx := &graphql.ResolverContext{
-		Object: "TestInstance",
-		Args:   nil,
-		Field:  field,
-	}
-	ctx = graphql.WithResolverContext(ctx, rctx)
-	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
-		return ec.resolvers.TestInstance().EndAt(ctx, obj)
-	})
-	if resTmp == nil {
-		return graphql.Null
+var testInstanceEdgeImplementors = []string{"TestInstanceEdge"}
+
+// nolint: gocyclo, errcheck, gas, goconst
+func (ec *executionContext) _TestInstanceEdge(ctx context.Context, sel ast.SelectionSet, obj *TestInstanceEdge) graphql.Marshaler {
+	fields := graphql.CollectFields(ctx, sel, testInstanceEdgeImplementors)
+
+	out := graphql.NewOrderedMap(len(fields))
+	invalid := false
+	for i, field := range fields {
+		out.Keys[i] = field.Alias
+
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("TestInstanceEdge")
+		case "cursor":
+			out.Values[i] = ec._TestInstanceEdge_cursor(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalid = true
+			}
+		case "node":
+			out.Values[i] = ec._TestInstanceEdge_node(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalid = true
+			}
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
 	}
-	res := resTmp.(*time.Time)
-	rctx.Result = res
 
-	if res == nil {
+	if invalid {
 		return graphql.Null
 	}
-	return graphql.MarshalTime(*res)
+	return out
 }
 
 // nolint: vetshadow
-func (ec *executionContext) _TestInstance_pass(ctx context.Context, field graphql.CollectedField, obj *db.TestInstance) graphql.Marshaler {
+func (ec *executionContext) _TestInstanceEdge_cursor(ctx context.Context, field graphql.CollectedField, obj *TestInstanceEdge) graphql.Marshaler {
 	rctx := &graphql.ResolverContext{
-		Object: "TestInstance",
+		Object: "TestInstanceEdge",
 		Args:   nil,
 		Field:  field,
 	}
 	ctx = graphql.WithResolverContext(ctx, rctx)
 	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
-		return obj.Pass, nil
+		return obj.Cursor, nil
 	})
 	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(bool)
+	res := resTmp.(string)
 	rctx.Result = res
-	return graphql.MarshalBoolean(res)
+	return graphql.MarshalString(res)
 }
 
 // nolint: vetshadow
-func (ec *executionContext) _TestInstance_fail_cause(ctx context.Context, field graphql.CollectedField, obj *db.TestInstance) graphql.Marshaler {
+func (ec *executionContext) _TestInstanceEdge_node(ctx context.Context, field graphql.CollectedField, obj *TestInstanceEdge) graphql.Marshaler {
 	rctx := &graphql.ResolverContext{
-		Object: "TestInstance",
+		Object: "TestInstanceEdge",
 		Args:   nil,
 		Field:  field,
 	}
 	ctx = graphql.WithResolverContext(ctx, rctx)
 	resTmp := ec.FieldMiddleware(ctx, obj, func(ctx context.Context) (interface{}, error) {
-		return ec.resolvers.TestInstance().FailCause(ctx, obj)
+		return obj.Node, nil
 	})
 	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.(db.TestInstance)
 	rctx.Result = res
 
-	if res == nil {
-		return graphql.Null
-	}
-	return graphql.MarshalString(*res)
+	return ec._TestInstance(ctx, field.Selections, &res)
 }
 
 var __DirectiveImplementors = []string{"__Directive"}
@@ -1971,6 +7445,30 @@ func (ec *executionContext) ___Type_ofType(ctx context.Context, field graphql.Co
 	return ec.___Type(ctx, field.Selections, res)
 }
 
+func UnmarshalLabelInput(v interface{}) (LabelInput, error) {
+	var it LabelInput
+	var asMap = v.(map[string]interface{})
+
+	for k, v := range asMap {
+		switch k {
+		case "name":
+			var err error
+			it.Name, err = graphql.UnmarshalString(v)
+			if err != nil {
+				return it, err
+			}
+		case "value":
+			var err error
+			it.Value, err = graphql.UnmarshalString(v)
+			if err != nil {
+				return it, err
+			}
+		}
+	}
+
+	return it, nil
+}
+
 func (ec *executionContext) FieldMiddleware(ctx context.Context, obj interface{}, next graphql.Resolver) (ret interface{}) {
 	defer func() {
 		if r := recover(); r != nil {
@@ -2002,12 +7500,227 @@ var parsedSchema = gqlparser.MustLoadSchema(
   end_at: Time
   pass: Boolean
   fail_cause: String
+  fail_kind: FailureKind
+}
+
+# FailureKind tells what made a run fail: the test timing out, failing a check,
+# its script throwing an error, its requests failing to connect, to handshake
+# or to resolve their host, or the canary failing to set the run up.
+enum FailureKind {
+  TIMEOUT
+  ASSERTION
+  SCRIPT_ERROR
+  NETWORK
+  TLS
+  DNS
+  INFRASTRUCTURE
+}
+
+type LogField {
+  key: String!
+  value: String!
+}
+
+# LogEntry is a line logged by a run of a test.
+type LogEntry {
+  time: Time!
+  level: String!
+  message: String!
+  fields: [LogField!]!
+}
+
+type TestInstanceEdge {
+  cursor: String!
+  node: TestInstance!
+}
+
+type PageInfo {
+  hasNextPage: Boolean!
+  hasPreviousPage: Boolean!
+  startCursor: String
+  endCursor: String
+}
+
+# TestInstanceConnection is a page of runs, most recently started first.
+type TestInstanceConnection {
+  edges: [TestInstanceEdge!]!
+  pageInfo: PageInfo!
+  totalCount: Int!
+}
+
+enum TestStatus {
+  UNKNOWN
+  PAUSED
+  RUNNING
+  PASSING
+  FAILING
+}
+
+# Pause keeps a test from running until it's resumed, or until a given time.
+type Pause {
+  until: Time
+  reason: String!
+  paused_by: String!
+  paused_at: Time!
+}
+
+enum AlertState {
+  OK
+  FIRING
+}
+
+# Alert is where a test stands with regard to alerting, as of its last run.
+# Notifications are held back while it's flapping.
+type Alert {
+  name: String!
+  state: AlertState!
+  since: Time
+  flapping: Boolean!
+  consecutive_failures: Int!
+  consecutive_passes: Int!
+  last_run: TestInstance
+}
+
+# AlertTransition is a change in the alert of a test, made by one of its runs.
+type AlertTransition {
+  at: Time!
+  state: AlertState!
+  flapping: Boolean!
+  run: TestInstance
+}
+
+# DeliveryAttempt is an attempt at notifying about an alert, or the record that
+# the notification was held back by silenced_by. Latencies are in seconds.
+type DeliveryAttempt {
+  delivery_id: ID!
+  notifier: String!
+  attempt: Int!
+  at: Time!
+  latency: Float!
+  status_code: Int
+  error: String
+  final: Boolean!
+  silenced_by: String
+  run: TestInstance
+}
+
+# AlertHistory is what came of the alerts of a test between from and to.
+type AlertHistory {
+  name: String!
+  from: Time!
+  to: Time!
+  transitions: [AlertTransition!]!
+  deliveries: [DeliveryAttempt!]!
+}
+
+type Label {
+  name: String!
+  value: String!
+}
+
+input LabelInput {
+  name: String!
+  value: String!
+}
+
+# Silence holds back the notifications about the tests whose name matches the
+# glob in tests, if any, and which have all the labels, from start_at to
+# end_at. Their alerts keep being tracked.
+type Silence {
+  id: ID!
+  tests: String
+  labels: [Label!]!
+  start_at: Time!
+  end_at: Time!
+  comment: String!
+  created_by: String!
+  created_at: Time!
+  active: Boolean!
+}
+
+# TestDefinition is a test as configured. Durations are in seconds.
+type TestDefinition {
+  name: String!
+  canary: String!
+  frequency: Float!
+  timeout: Float!
+  source: String!
+  labels: [Label!]!
+  slo: SLOReport
+  status: TestStatus!
+  last_run: TestInstance
+  last_success: TestInstance
+  next_run_at: Time
+  pause: Pause
+  runs(pass: Boolean, fail_kind: FailureKind, from: Time, to: Time, first: Int = 50, after: String): TestInstanceConnection!
+}
+
+enum Granularity {
+  HOUR
+  DAY
+}
+
+# Rollup aggregates the runs of a test that started within the same hour or
+# day. Durations are in seconds.
+type Rollup {
+  name: String!
+  granularity: Granularity!
+  start_at: Time!
+  runs: Int!
+  passes: Int!
+  failures: Int!
+  min_duration: Float!
+  avg_duration: Float!
+  max_duration: Float!
+  p95_duration: Float!
+}
+
+# SLOReport tells how a test is doing against its SLO, over the window of the
+# SLO. Ratios are between 0 and 1.
+type SLOReport {
+  name: String!
+  target: Float!
+  window: String!
+  success_ratio: Float!
+  error_budget_remaining: Float!
+  burn_rates: [BurnRate!]!
+}
+
+# BurnRate tells how fast the error budget was spent over a window. At a rate
+# of 1, the budget is spent exactly by the end of the window of the SLO.
+type BurnRate {
+  window: String!
+  runs: Int!
+  success_ratio: Float!
+  rate: Float!
 }
 
 type Query {
- tests: [TestInstance!]!
+ runs(name: String, pass: Boolean, fail_kind: FailureKind, from: Time, to: Time, first: Int = 50, after: String): TestInstanceConnection!
  test(id: String!): TestInstance
  ongoingTests: [TestInstance!]!
+ definitions: [TestDefinition!]!
+ definition(name: String!): TestDefinition
+ stats(name: String!, from: Time!, to: Time!, granularity: Granularity!): [Rollup!]!
+ slo(name: String!): SLOReport
+ alerts: [Alert!]!
+ alertHistory(name: String!, from: Time, to: Time): AlertHistory!
+ silences(active: Boolean): [Silence!]!
+}
+
+# Mutations require the admin role.
+type Mutation {
+ pauseTest(name: String!, until: Time, reason: String!): TestDefinition!
+ resumeTest(name: String!): TestDefinition!
+ createSilence(tests: String, labels: [LabelInput!], start_at: Time, end_at: Time!, comment: String!): Silence!
+ expireSilence(id: ID!): Silence!
+}
+
+# Subscriptions are served over websockets, on the same endpoint as queries.
+type Subscription {
+ runStarted: TestInstance!
+ runFinished(name: String): TestInstance!
+ runLog(id: ID!): LogEntry!
 }
 
 scalar Time
@@ -0,0 +1,106 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/iheanyi/simple-canary/internal/buildinfo"
+)
+
+// stuckGrace is how long a run may go past its timeout before it's
+// considered stuck.
+const stuckGrace = 30 * time.Second
+
+type probeResult struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks"`
+}
+
+type versionResult struct {
+	buildinfo.Info
+	ConfigHash string `json:"config_hash"`
+}
+
+// probes sets up the endpoints telling whether the canary is alive and ready,
+// and which build it is. They are meant for orchestrators, so unlike the rest
+// they don't require any role.
+func (app *App) probes(r *mux.Router) {
+	r.HandleFunc("/healthz", app.healthz).Methods("GET", "HEAD")
+	r.HandleFunc("/readyz", app.readyz).Methods("GET", "HEAD")
+	r.HandleFunc("/version", app.version).Methods("GET")
+}
+
+// healthz checks that the process is up and can write to the database.
+func (app *App) healthz(w http.ResponseWriter, r *http.Request) {
+	checks := map[string]error{
+		"db": app.db.Ping(),
+	}
+	writeProbe(w, checks)
+}
+
+// readyz checks that the tests are all scheduled, and that none of them is
+// stuck running past its timeout.
+func (app *App) readyz(w http.ResponseWriter, r *http.Request) {
+	checks := map[string]error{
+		"config":    nil,
+		"scheduler": nil,
+		"runs":      nil,
+	}
+	if app.res.canary == nil {
+		checks["config"] = fmt.Errorf("not loaded")
+	}
+	for _, cfg := range app.res.configs {
+		if !app.res.sched.Scheduled(cfg.Name) {
+			checks["scheduler"] = fmt.Errorf("test %q isn't scheduled", cfg.Name)
+			break
+		}
+	}
+	ongoing, err := app.db.ListOngoingTests()
+	if err != nil {
+		checks["runs"] = err
+	}
+	now := time.Now()
+	for _, run := range ongoing {
+		cfg, ok := app.res.tests[run.TestName]
+		if !ok {
+			continue
+		}
+		if now.Sub(run.StartAt) > cfg.Timeout+stuckGrace {
+			checks["runs"] = fmt.Errorf("run %s of test %q is stuck since %s", run.TestID, run.TestName, run.StartAt.Format(time.RFC3339))
+			break
+		}
+	}
+	writeProbe(w, checks)
+}
+
+func (app *App) version(w http.ResponseWriter, r *http.Request) {
+	res := versionResult{Info: buildinfo.Read()}
+	if app.res.canary != nil {
+		res.ConfigHash = app.res.canary.Hash
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+// writeProbe responds with the outcome of each check, and with 503 if any of
+// them failed.
+func writeProbe(w http.ResponseWriter, checks map[string]error) {
+	res := probeResult{Status: "ok", Checks: make(map[string]string, len(checks))}
+	code := http.StatusOK
+	for name, err := range checks {
+		if err != nil {
+			res.Status = "unavailable"
+			res.Checks[name] = err.Error()
+			code = http.StatusServiceUnavailable
+		} else {
+			res.Checks[name] = "ok"
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(res)
+}
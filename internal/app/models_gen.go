@@ -0,0 +1,205 @@
+// Code generated by github.com/99designs/gqlgen, DO NOT EDIT.
+
+package app
+
+import (
+	fmt "fmt"
+	io "io"
+	strconv "strconv"
+	time "time"
+
+	db "github.com/iheanyi/simple-canary/internal/db"
+)
+
+type AlertHistory struct {
+	Name        string               `json:"name"`
+	From        time.Time            `json:"from"`
+	To          time.Time            `json:"to"`
+	Transitions []db.AlertTransition `json:"transitions"`
+	Deliveries  []db.DeliveryAttempt `json:"deliveries"`
+}
+
+type Label struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type LabelInput struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type LogField struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+type PageInfo struct {
+	HasNextPage     bool    `json:"hasNextPage"`
+	HasPreviousPage bool    `json:"hasPreviousPage"`
+	StartCursor     *string `json:"startCursor"`
+	EndCursor       *string `json:"endCursor"`
+}
+
+type TestInstanceConnection struct {
+	Edges      []TestInstanceEdge `json:"edges"`
+	PageInfo   PageInfo           `json:"pageInfo"`
+	TotalCount int                `json:"totalCount"`
+}
+
+type TestInstanceEdge struct {
+	Cursor string          `json:"cursor"`
+	Node   db.TestInstance `json:"node"`
+}
+
+type AlertState string
+
+const (
+	AlertStateOk     AlertState = "OK"
+	AlertStateFiring AlertState = "FIRING"
+)
+
+func (e AlertState) IsValid() bool {
+	switch e {
+	case AlertStateOk, AlertStateFiring:
+		return true
+	}
+	return false
+}
+
+func (e AlertState) String() string {
+	return string(e)
+}
+
+func (e *AlertState) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = AlertState(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid AlertState", str)
+	}
+	return nil
+}
+
+func (e AlertState) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type FailureKind string
+
+const (
+	FailureKindTimeout        FailureKind = "TIMEOUT"
+	FailureKindAssertion      FailureKind = "ASSERTION"
+	FailureKindScriptError    FailureKind = "SCRIPT_ERROR"
+	FailureKindNetwork        FailureKind = "NETWORK"
+	FailureKindTls            FailureKind = "TLS"
+	FailureKindDns            FailureKind = "DNS"
+	FailureKindInfrastructure FailureKind = "INFRASTRUCTURE"
+)
+
+func (e FailureKind) IsValid() bool {
+	switch e {
+	case FailureKindTimeout, FailureKindAssertion, FailureKindScriptError, FailureKindNetwork, FailureKindTls, FailureKindDns, FailureKindInfrastructure:
+		return true
+	}
+	return false
+}
+
+func (e FailureKind) String() string {
+	return string(e)
+}
+
+func (e *FailureKind) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = FailureKind(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid FailureKind", str)
+	}
+	return nil
+}
+
+func (e FailureKind) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type Granularity string
+
+const (
+	GranularityHour Granularity = "HOUR"
+	GranularityDay  Granularity = "DAY"
+)
+
+func (e Granularity) IsValid() bool {
+	switch e {
+	case GranularityHour, GranularityDay:
+		return true
+	}
+	return false
+}
+
+func (e Granularity) String() string {
+	return string(e)
+}
+
+func (e *Granularity) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = Granularity(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid Granularity", str)
+	}
+	return nil
+}
+
+func (e Granularity) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type TestStatus string
+
+const (
+	TestStatusUnknown TestStatus = "UNKNOWN"
+	TestStatusPaused  TestStatus = "PAUSED"
+	TestStatusRunning TestStatus = "RUNNING"
+	TestStatusPassing TestStatus = "PASSING"
+	TestStatusFailing TestStatus = "FAILING"
+)
+
+func (e TestStatus) IsValid() bool {
+	switch e {
+	case TestStatusUnknown, TestStatusPaused, TestStatusRunning, TestStatusPassing, TestStatusFailing:
+		return true
+	}
+	return false
+}
+
+func (e TestStatus) String() string {
+	return string(e)
+}
+
+func (e *TestStatus) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = TestStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid TestStatus", str)
+	}
+	return nil
+}
+
+func (e TestStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
@@ -4,27 +4,303 @@ package app
 
 import (
 	context "context"
+	fmt "fmt"
+	path "path"
+	sort "sort"
+	strings "strings"
 	time "time"
 
+	"github.com/iheanyi/simple-canary/internal/auth"
 	dbpkg "github.com/iheanyi/simple-canary/internal/db"
+	"github.com/iheanyi/simple-canary/internal/events"
+	"github.com/iheanyi/simple-canary/internal/js"
+	"github.com/iheanyi/simple-canary/internal/js/canary"
+	"github.com/iheanyi/simple-canary/internal/scheduler"
+	"github.com/iheanyi/simple-canary/internal/slo"
+	"github.com/pborman/uuid"
+	"github.com/sirupsen/logrus"
 )
 
 type Resolver struct {
-	db dbpkg.CanaryStore
+	l      logrus.FieldLogger
+	db     dbpkg.CanaryStore
+	bus    *events.Bus
+	sched  *scheduler.Scheduler
+	canary *canary.Config
+	// configs are the tests in the order in which they're configured.
+	configs []*js.TestConfig
+	tests   map[string]*js.TestConfig
 }
 
+func (r *Resolver) Alert() AlertResolver {
+	return &alertResolver{r}
+}
+
+func (r *Resolver) AlertTransition() AlertTransitionResolver {
+	return &alertTransitionResolver{r}
+}
+
+func (r *Resolver) BurnRate() BurnRateResolver {
+	return &burnRateResolver{r}
+}
+
+func (r *Resolver) DeliveryAttempt() DeliveryAttemptResolver {
+	return &deliveryAttemptResolver{r}
+}
+
+func (r *Resolver) LogEntry() LogEntryResolver {
+	return &logEntryResolver{r}
+}
+func (r *Resolver) Mutation() MutationResolver {
+	return &mutationResolver{r}
+}
+func (r *Resolver) Pause() PauseResolver {
+	return &pauseResolver{r}
+}
 func (r *Resolver) Query() QueryResolver {
 	return &queryResolver{r}
 }
+func (r *Resolver) Rollup() RollupResolver {
+	return &rollupResolver{r}
+}
+func (r *Resolver) SLOReport() SLOReportResolver {
+	return &sLOReportResolver{r}
+}
+func (r *Resolver) Silence() SilenceResolver {
+	return &silenceResolver{r}
+}
+func (r *Resolver) Subscription() SubscriptionResolver {
+	return &subscriptionResolver{r}
+}
+func (r *Resolver) TestDefinition() TestDefinitionResolver {
+	return &testDefinitionResolver{r}
+}
 func (r *Resolver) TestInstance() TestInstanceResolver {
 	return &testInstanceResolver{r}
 }
 
+type logEntryResolver struct{ *Resolver }
+
+func (r *logEntryResolver) Fields(ctx context.Context, obj *dbpkg.LogEntry) ([]LogField, error) {
+	fields := make([]LogField, 0, len(obj.Fields))
+	for k, v := range obj.Fields {
+		fields = append(fields, LogField{Key: k, Value: v})
+	}
+	sort.Slice(fields, func(i, j int) bool { return fields[i].Key < fields[j].Key })
+	return fields, nil
+}
+
+type mutationResolver struct{ *Resolver }
+
+func (r *mutationResolver) PauseTest(ctx context.Context, name string, until *time.Time, reason string) (js.TestConfig, error) {
+	p := auth.FromContext(ctx)
+	if p.Role < auth.Admin {
+		return js.TestConfig{}, fmt.Errorf("pausing tests requires the admin role")
+	}
+	cfg, ok := r.tests[name]
+	if !ok {
+		return js.TestConfig{}, fmt.Errorf("no test named %q", name)
+	}
+	pause := dbpkg.Pause{
+		TestName: name,
+		Reason:   reason,
+		PausedBy: p.Name,
+		PausedAt: time.Now().UTC(),
+	}
+	if until != nil {
+		if !until.After(pause.PausedAt) {
+			return js.TestConfig{}, fmt.Errorf("until must be in the future, was %v", *until)
+		}
+		pause.Until = until.UTC()
+	}
+
+	if err := r.db.PauseTest(pause); err != nil {
+		return js.TestConfig{}, err
+	}
+	r.sched.Pause(name, pause.Until)
+	r.l.WithFields(logrus.Fields{
+		"test.name": name,
+		"until":     pause.Until,
+		"reason":    reason,
+		"paused_by": p.Name,
+	}).Info("test paused")
+	return *cfg, nil
+}
+
+func (r *mutationResolver) ResumeTest(ctx context.Context, name string) (js.TestConfig, error) {
+	p := auth.FromContext(ctx)
+	if p.Role < auth.Admin {
+		return js.TestConfig{}, fmt.Errorf("resuming tests requires the admin role")
+	}
+	cfg, ok := r.tests[name]
+	if !ok {
+		return js.TestConfig{}, fmt.Errorf("no test named %q", name)
+	}
+	if err := r.db.ResumeTest(name); err != nil {
+		return js.TestConfig{}, err
+	}
+	r.sched.Resume(name)
+	r.l.WithFields(logrus.Fields{
+		"test.name":  name,
+		"resumed_by": p.Name,
+	}).Info("test resumed")
+	return *cfg, nil
+}
+
+func (r *mutationResolver) CreateSilence(ctx context.Context, tests *string, labels []LabelInput, start_at *time.Time, end_at time.Time, comment string) (dbpkg.Silence, error) {
+	p := auth.FromContext(ctx)
+	if p.Role < auth.Admin {
+		return dbpkg.Silence{}, fmt.Errorf("silencing tests requires the admin role")
+	}
+	silence := dbpkg.Silence{
+		ID:        uuid.New(),
+		EndAt:     end_at.UTC(),
+		Comment:   comment,
+		CreatedBy: p.Name,
+		CreatedAt: time.Now().UTC(),
+	}
+	silence.StartAt = silence.CreatedAt
+	if start_at != nil {
+		silence.StartAt = start_at.UTC()
+	}
+	if tests != nil {
+		if _, err := path.Match(*tests, ""); err != nil {
+			return dbpkg.Silence{}, fmt.Errorf("invalid tests %q: %v", *tests, err)
+		}
+		silence.Tests = *tests
+	}
+	if len(labels) > 0 {
+		silence.Labels = make(map[string]string, len(labels))
+		for _, l := range labels {
+			silence.Labels[l.Name] = l.Value
+		}
+	}
+	switch {
+	case silence.Tests == "" && len(silence.Labels) == 0:
+		return dbpkg.Silence{}, fmt.Errorf("silence needs tests or labels to match")
+	case !silence.EndAt.After(silence.StartAt):
+		return dbpkg.Silence{}, fmt.Errorf("end_at must be after start_at, was %v", end_at)
+	case !silence.EndAt.After(silence.CreatedAt):
+		return dbpkg.Silence{}, fmt.Errorf("end_at must be in the future, was %v", end_at)
+	}
+
+	if err := r.db.PutSilence(silence); err != nil {
+		return dbpkg.Silence{}, err
+	}
+	r.l.WithFields(logrus.Fields{
+		"silence.id": silence.ID,
+		"tests":      silence.Tests,
+		"labels":     silence.Labels,
+		"start_at":   silence.StartAt,
+		"end_at":     silence.EndAt,
+		"comment":    comment,
+		"created_by": p.Name,
+	}).Info("silence created")
+	return silence, nil
+}
+
+// ExpireSilence ends the silence now, or cancels it if it hasn't started.
+func (r *mutationResolver) ExpireSilence(ctx context.Context, id string) (dbpkg.Silence, error) {
+	p := auth.FromContext(ctx)
+	if p.Role < auth.Admin {
+		return dbpkg.Silence{}, fmt.Errorf("expiring silences requires the admin role")
+	}
+	silences, err := r.db.ListSilences()
+	if err != nil {
+		return dbpkg.Silence{}, err
+	}
+	for _, silence := range silences {
+		if silence.ID != id {
+			continue
+		}
+		now := time.Now().UTC()
+		if !silence.EndAt.After(now) {
+			return silence, nil
+		}
+		silence.EndAt = now
+		if silence.StartAt.After(now) {
+			silence.StartAt = now
+		}
+		if err := r.db.PutSilence(silence); err != nil {
+			return dbpkg.Silence{}, err
+		}
+		r.l.WithFields(logrus.Fields{
+			"silence.id": id,
+			"expired_by": p.Name,
+		}).Info("silence expired")
+		return silence, nil
+	}
+	return dbpkg.Silence{}, fmt.Errorf("no silence with ID %q", id)
+}
+
+type pauseResolver struct{ *Resolver }
+
+func (r *pauseResolver) Until(ctx context.Context, obj *dbpkg.Pause) (*time.Time, error) {
+	if obj.Until.IsZero() {
+		return nil, nil
+	}
+	return &obj.Until, nil
+}
+func (r *pauseResolver) PausedBy(ctx context.Context, obj *dbpkg.Pause) (string, error) {
+	return obj.PausedBy, nil
+}
+func (r *pauseResolver) PausedAt(ctx context.Context, obj *dbpkg.Pause) (time.Time, error) {
+	return obj.PausedAt, nil
+}
+
 type queryResolver struct{ *Resolver }
 
-func (r *queryResolver) Tests(ctx context.Context) ([]dbpkg.TestInstance, error) {
-	tests, err := r.db.ListTests()
-	return tests, err
+func (r *queryResolver) Runs(ctx context.Context, name *string, pass *bool, failKind *FailureKind, from *time.Time, to *time.Time, first int, after *string) (TestInstanceConnection, error) {
+	filter := dbpkg.RunFilter{Pass: pass}
+	if name != nil {
+		filter.Name = *name
+	}
+	if failKind != nil {
+		filter.FailKind = dbFailureKind(*failKind)
+	}
+	if from != nil {
+		filter.From = *from
+	}
+	if to != nil {
+		filter.To = *to
+	}
+	return r.runs(filter, first, after)
+}
+
+// maxRunsPage is the most runs that can be asked for at once.
+const maxRunsPage = 1000
+
+func (r *Resolver) runs(filter dbpkg.RunFilter, first int, after *string) (TestInstanceConnection, error) {
+	if first < 1 || first > maxRunsPage {
+		return TestInstanceConnection{}, fmt.Errorf("first must be between 1 and %d, was %d", maxRunsPage, first)
+	}
+	var cursor string
+	if after != nil {
+		cursor = *after
+	}
+
+	page, err := r.db.ListRuns(filter, first, cursor)
+	if err != nil {
+		return TestInstanceConnection{}, err
+	}
+	conn := TestInstanceConnection{
+		Edges:      make([]TestInstanceEdge, 0, len(page.Runs)),
+		TotalCount: page.TotalCount,
+		PageInfo: PageInfo{
+			HasNextPage: page.HasNextPage,
+		},
+	}
+	for i := range page.Runs {
+		conn.Edges = append(conn.Edges, TestInstanceEdge{
+			Cursor: dbpkg.RunCursor(&page.Runs[i]),
+			Node:   page.Runs[i],
+		})
+	}
+	if len(conn.Edges) > 0 {
+		conn.PageInfo.StartCursor = &conn.Edges[0].Cursor
+		conn.PageInfo.EndCursor = &conn.Edges[len(conn.Edges)-1].Cursor
+	}
+	return conn, nil
 }
 
 func (r *queryResolver) Test(ctx context.Context, id string) (*dbpkg.TestInstance, error) {
@@ -37,6 +313,449 @@ func (r *queryResolver) OngoingTests(ctx context.Context) ([]dbpkg.TestInstance,
 	return tests, err
 }
 
+func (r *queryResolver) Definitions(ctx context.Context) ([]js.TestConfig, error) {
+	defs := make([]js.TestConfig, 0, len(r.configs))
+	for _, cfg := range r.configs {
+		defs = append(defs, *cfg)
+	}
+	return defs, nil
+}
+
+func (r *queryResolver) Definition(ctx context.Context, name string) (*js.TestConfig, error) {
+	cfg, ok := r.tests[name]
+	if !ok {
+		return nil, nil
+	}
+	return cfg, nil
+}
+
+func (r *queryResolver) Stats(ctx context.Context, name string, from time.Time, to time.Time, granularity Granularity) ([]dbpkg.Rollup, error) {
+	return r.db.ListRollups(name, granularities[granularity], from, to)
+}
+
+func (r *queryResolver) Slo(ctx context.Context, name string) (*slo.Report, error) {
+	cfg, ok := r.tests[name]
+	if !ok {
+		return nil, fmt.Errorf("no test named %q", name)
+	}
+	return r.slo(cfg)
+}
+
+// Alerts lists the alert state of each test, in the order in which they're
+// configured. Tests that haven't run yet aren't firing.
+func (r *queryResolver) Alerts(ctx context.Context) ([]dbpkg.AlertState, error) {
+	states, err := r.db.ListAlertStates()
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]dbpkg.AlertState, len(states))
+	for _, state := range states {
+		byName[state.TestName] = state
+	}
+	alerts := make([]dbpkg.AlertState, 0, len(r.configs))
+	for _, cfg := range r.configs {
+		state, ok := byName[cfg.Name]
+		if !ok {
+			state = dbpkg.AlertState{TestName: cfg.Name}
+		}
+		alerts = append(alerts, state)
+	}
+	return alerts, nil
+}
+
+// AlertHistory tells what came of the alerts of the named test, over the week
+// before to unless from is given.
+func (r *queryResolver) AlertHistory(ctx context.Context, name string, from *time.Time, to *time.Time) (AlertHistory, error) {
+	if _, ok := r.tests[name]; !ok {
+		return AlertHistory{}, fmt.Errorf("no test named %q", name)
+	}
+	end := time.Now()
+	if to != nil {
+		end = *to
+	}
+	start := end.Add(-7 * 24 * time.Hour)
+	if from != nil {
+		start = *from
+	}
+	return r.alertHistory(name, start, end)
+}
+
+func (r *Resolver) alertHistory(name string, from, to time.Time) (AlertHistory, error) {
+	transitions, err := r.db.ListAlertTransitions(name, from, to)
+	if err != nil {
+		return AlertHistory{}, err
+	}
+	deliveries, err := r.db.ListDeliveryAttempts(name, from, to)
+	if err != nil {
+		return AlertHistory{}, err
+	}
+	return AlertHistory{
+		Name:        name,
+		From:        from,
+		To:          to,
+		Transitions: transitions,
+		Deliveries:  deliveries,
+	}, nil
+}
+
+// Silences lists the silences, those that are active or not if asked, in the
+// order in which they start.
+func (r *queryResolver) Silences(ctx context.Context, active *bool) ([]dbpkg.Silence, error) {
+	silences, err := r.db.ListSilences()
+	if err != nil || active == nil {
+		return silences, err
+	}
+	now := time.Now()
+	selected := make([]dbpkg.Silence, 0, len(silences))
+	for _, silence := range silences {
+		if silence.Active(now) == *active {
+			selected = append(selected, silence)
+		}
+	}
+	return selected, nil
+}
+
+func (r *Resolver) slo(cfg *js.TestConfig) (*slo.Report, error) {
+	if cfg.SLO == nil {
+		return nil, nil
+	}
+	return slo.Compute(r.db, cfg.Name, *cfg.SLO, time.Now())
+}
+
+var granularities = map[Granularity]dbpkg.Granularity{
+	GranularityHour: dbpkg.Hourly,
+	GranularityDay:  dbpkg.Daily,
+}
+
+type rollupResolver struct{ *Resolver }
+
+func (r *rollupResolver) Name(ctx context.Context, obj *dbpkg.Rollup) (string, error) {
+	return obj.TestName, nil
+}
+func (r *rollupResolver) Granularity(ctx context.Context, obj *dbpkg.Rollup) (Granularity, error) {
+	for g, dbg := range granularities {
+		if dbg == obj.Granularity {
+			return g, nil
+		}
+	}
+	return "", fmt.Errorf("unknown granularity: %q", obj.Granularity)
+}
+func (r *rollupResolver) StartAt(ctx context.Context, obj *dbpkg.Rollup) (time.Time, error) {
+	return obj.StartAt, nil
+}
+func (r *rollupResolver) MinDuration(ctx context.Context, obj *dbpkg.Rollup) (float64, error) {
+	return obj.MinDuration.Seconds(), nil
+}
+func (r *rollupResolver) AvgDuration(ctx context.Context, obj *dbpkg.Rollup) (float64, error) {
+	return obj.AvgDuration().Seconds(), nil
+}
+func (r *rollupResolver) MaxDuration(ctx context.Context, obj *dbpkg.Rollup) (float64, error) {
+	return obj.MaxDuration.Seconds(), nil
+}
+func (r *rollupResolver) P95Duration(ctx context.Context, obj *dbpkg.Rollup) (float64, error) {
+	return obj.Percentile(0.95).Seconds(), nil
+}
+
+type sLOReportResolver struct{ *Resolver }
+
+func (r *sLOReportResolver) Target(ctx context.Context, obj *slo.Report) (float64, error) {
+	return obj.Objective.Target, nil
+}
+func (r *sLOReportResolver) Window(ctx context.Context, obj *slo.Report) (string, error) {
+	return slo.FormatWindow(obj.Objective.Window), nil
+}
+func (r *sLOReportResolver) SuccessRatio(ctx context.Context, obj *slo.Report) (float64, error) {
+	return obj.SuccessRatio, nil
+}
+func (r *sLOReportResolver) ErrorBudgetRemaining(ctx context.Context, obj *slo.Report) (float64, error) {
+	return obj.ErrorBudgetRemaining, nil
+}
+func (r *sLOReportResolver) BurnRates(ctx context.Context, obj *slo.Report) ([]slo.BurnRate, error) {
+	return obj.BurnRates, nil
+}
+
+type alertResolver struct{ *Resolver }
+
+func (r *alertResolver) Name(ctx context.Context, obj *dbpkg.AlertState) (string, error) {
+	return obj.TestName, nil
+}
+func (r *alertResolver) State(ctx context.Context, obj *dbpkg.AlertState) (AlertState, error) {
+	if obj.Firing {
+		return AlertStateFiring, nil
+	}
+	return AlertStateOk, nil
+}
+func (r *alertResolver) Since(ctx context.Context, obj *dbpkg.AlertState) (*time.Time, error) {
+	if obj.Since.IsZero() {
+		return nil, nil
+	}
+	return &obj.Since, nil
+}
+func (r *alertResolver) ConsecutiveFailures(ctx context.Context, obj *dbpkg.AlertState) (int, error) {
+	return obj.ConsecutiveFailures, nil
+}
+func (r *alertResolver) ConsecutivePasses(ctx context.Context, obj *dbpkg.AlertState) (int, error) {
+	return obj.ConsecutivePasses, nil
+}
+func (r *alertResolver) LastRun(ctx context.Context, obj *dbpkg.AlertState) (*dbpkg.TestInstance, error) {
+	if obj.LastRunID == "" {
+		return nil, nil
+	}
+	return r.db.FindTestByID(obj.LastRunID)
+}
+
+type alertTransitionResolver struct{ *Resolver }
+
+func (r *alertTransitionResolver) State(ctx context.Context, obj *dbpkg.AlertTransition) (AlertState, error) {
+	if obj.Firing {
+		return AlertStateFiring, nil
+	}
+	return AlertStateOk, nil
+}
+func (r *alertTransitionResolver) Run(ctx context.Context, obj *dbpkg.AlertTransition) (*dbpkg.TestInstance, error) {
+	return r.findRun(obj.RunID)
+}
+
+type deliveryAttemptResolver struct{ *Resolver }
+
+func (r *deliveryAttemptResolver) DeliveryID(ctx context.Context, obj *dbpkg.DeliveryAttempt) (string, error) {
+	return obj.DeliveryID, nil
+}
+func (r *deliveryAttemptResolver) Latency(ctx context.Context, obj *dbpkg.DeliveryAttempt) (float64, error) {
+	return obj.Latency.Seconds(), nil
+}
+func (r *deliveryAttemptResolver) StatusCode(ctx context.Context, obj *dbpkg.DeliveryAttempt) (*int, error) {
+	if obj.StatusCode == 0 {
+		return nil, nil
+	}
+	return &obj.StatusCode, nil
+}
+func (r *deliveryAttemptResolver) Error(ctx context.Context, obj *dbpkg.DeliveryAttempt) (*string, error) {
+	if obj.Error == "" {
+		return nil, nil
+	}
+	return &obj.Error, nil
+}
+func (r *deliveryAttemptResolver) SilencedBy(ctx context.Context, obj *dbpkg.DeliveryAttempt) (*string, error) {
+	if obj.SilencedBy == "" {
+		return nil, nil
+	}
+	return &obj.SilencedBy, nil
+}
+func (r *deliveryAttemptResolver) Run(ctx context.Context, obj *dbpkg.DeliveryAttempt) (*dbpkg.TestInstance, error) {
+	return r.findRun(obj.RunID)
+}
+
+// findRun returns the run with the given ID, if any. The history of alerts
+// is pruned along with the runs.
+func (r *Resolver) findRun(id string) (*dbpkg.TestInstance, error) {
+	if id == "" {
+		return nil, nil
+	}
+	return r.db.FindTestByID(id)
+}
+
+type silenceResolver struct{ *Resolver }
+
+func (r *silenceResolver) Tests(ctx context.Context, obj *dbpkg.Silence) (*string, error) {
+	if obj.Tests == "" {
+		return nil, nil
+	}
+	return &obj.Tests, nil
+}
+func (r *silenceResolver) Labels(ctx context.Context, obj *dbpkg.Silence) ([]Label, error) {
+	return labelList(obj.Labels), nil
+}
+func (r *silenceResolver) StartAt(ctx context.Context, obj *dbpkg.Silence) (time.Time, error) {
+	return obj.StartAt, nil
+}
+func (r *silenceResolver) EndAt(ctx context.Context, obj *dbpkg.Silence) (time.Time, error) {
+	return obj.EndAt, nil
+}
+func (r *silenceResolver) CreatedBy(ctx context.Context, obj *dbpkg.Silence) (string, error) {
+	return obj.CreatedBy, nil
+}
+func (r *silenceResolver) CreatedAt(ctx context.Context, obj *dbpkg.Silence) (time.Time, error) {
+	return obj.CreatedAt, nil
+}
+func (r *silenceResolver) Active(ctx context.Context, obj *dbpkg.Silence) (bool, error) {
+	return obj.Active(time.Now()), nil
+}
+
+// labelList lists the labels sorted by name.
+func labelList(labels map[string]string) []Label {
+	list := make([]Label, 0, len(labels))
+	for k, v := range labels {
+		list = append(list, Label{Name: k, Value: v})
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list
+}
+
+type burnRateResolver struct{ *Resolver }
+
+func (r *burnRateResolver) Window(ctx context.Context, obj *slo.BurnRate) (string, error) {
+	return slo.FormatWindow(obj.Window), nil
+}
+func (r *burnRateResolver) SuccessRatio(ctx context.Context, obj *slo.BurnRate) (float64, error) {
+	return obj.SuccessRatio, nil
+}
+
+type subscriptionResolver struct{ *Resolver }
+
+func (r *subscriptionResolver) RunStarted(ctx context.Context) (<-chan dbpkg.TestInstance, error) {
+	return r.runEvents(ctx, func(e events.Event) bool {
+		return e.Kind == events.RunStarted
+	}), nil
+}
+func (r *subscriptionResolver) RunFinished(ctx context.Context, name *string) (<-chan dbpkg.TestInstance, error) {
+	return r.runEvents(ctx, func(e events.Event) bool {
+		return e.Kind == events.RunFinished && (name == nil || e.Run.TestName == *name)
+	}), nil
+}
+func (r *subscriptionResolver) RunLog(ctx context.Context, id string) (<-chan dbpkg.LogEntry, error) {
+	logs := make(chan dbpkg.LogEntry)
+	go func() {
+		defer close(logs)
+		for e := range r.bus.Subscribe(ctx) {
+			if e.Kind != events.RunLog || e.Run.TestID != id {
+				continue
+			}
+			select {
+			case logs <- *e.Log:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return logs, nil
+}
+
+// runEvents sends the runs of the events that match, until the subscription
+// is over.
+func (r *subscriptionResolver) runEvents(ctx context.Context, match func(events.Event) bool) <-chan dbpkg.TestInstance {
+	runs := make(chan dbpkg.TestInstance)
+	go func() {
+		defer close(runs)
+		for e := range r.bus.Subscribe(ctx) {
+			if !match(e) {
+				continue
+			}
+			select {
+			case runs <- e.Run:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return runs
+}
+
+type testDefinitionResolver struct{ *Resolver }
+
+func (r *testDefinitionResolver) Canary(ctx context.Context, obj *js.TestConfig) (string, error) {
+	return r.canary.Name, nil
+}
+func (r *testDefinitionResolver) Labels(ctx context.Context, obj *js.TestConfig) ([]Label, error) {
+	return labelList(obj.Labels), nil
+}
+func (r *testDefinitionResolver) Frequency(ctx context.Context, obj *js.TestConfig) (float64, error) {
+	return obj.Frequency.Seconds(), nil
+}
+func (r *testDefinitionResolver) Timeout(ctx context.Context, obj *js.TestConfig) (float64, error) {
+	return obj.Timeout.Seconds(), nil
+}
+func (r *testDefinitionResolver) Slo(ctx context.Context, obj *js.TestConfig) (*slo.Report, error) {
+	return r.slo(obj)
+}
+func (r *testDefinitionResolver) Status(ctx context.Context, obj *js.TestConfig) (TestStatus, error) {
+	return r.status(obj.Name)
+}
+func (r *testDefinitionResolver) LastRun(ctx context.Context, obj *js.TestConfig) (*dbpkg.TestInstance, error) {
+	return r.lastRun(dbpkg.RunFilter{Name: obj.Name})
+}
+func (r *testDefinitionResolver) LastSuccess(ctx context.Context, obj *js.TestConfig) (*dbpkg.TestInstance, error) {
+	pass := true
+	return r.lastRun(dbpkg.RunFilter{Name: obj.Name, Pass: &pass})
+}
+func (r *testDefinitionResolver) NextRunAt(ctx context.Context, obj *js.TestConfig) (*time.Time, error) {
+	next, ok := r.sched.NextRun(obj.Name)
+	if !ok {
+		return nil, nil
+	}
+	return &next, nil
+}
+func (r *testDefinitionResolver) Pause(ctx context.Context, obj *js.TestConfig) (*dbpkg.Pause, error) {
+	return r.activePause(obj.Name)
+}
+func (r *testDefinitionResolver) Runs(ctx context.Context, obj *js.TestConfig, pass *bool, failKind *FailureKind, from *time.Time, to *time.Time, first int, after *string) (TestInstanceConnection, error) {
+	filter := dbpkg.RunFilter{Name: obj.Name, Pass: pass}
+	if failKind != nil {
+		filter.FailKind = dbFailureKind(*failKind)
+	}
+	if from != nil {
+		filter.From = *from
+	}
+	if to != nil {
+		filter.To = *to
+	}
+	return r.runs(filter, first, after)
+}
+
+// status tells how the named test is currently doing.
+func (r *Resolver) status(name string) (TestStatus, error) {
+	ongoing, err := r.db.ListOngoingTests()
+	if err != nil {
+		return "", err
+	}
+	for _, test := range ongoing {
+		if test.TestName == name {
+			return TestStatusRunning, nil
+		}
+	}
+
+	pause, err := r.activePause(name)
+	if err != nil {
+		return "", err
+	} else if pause != nil {
+		return TestStatusPaused, nil
+	}
+
+	last, err := r.lastRun(dbpkg.RunFilter{Name: name})
+	switch {
+	case err != nil:
+		return "", err
+	case last == nil:
+		return TestStatusUnknown, nil
+	case last.Pass:
+		return TestStatusPassing, nil
+	default:
+		return TestStatusFailing, nil
+	}
+}
+
+// activePause returns the pause of the named test, if it's in effect.
+func (r *Resolver) activePause(name string) (*dbpkg.Pause, error) {
+	pauses, err := r.db.ListPauses()
+	if err != nil {
+		return nil, err
+	}
+	for i := range pauses {
+		if pauses[i].TestName == name && pauses[i].Active(time.Now()) {
+			return &pauses[i], nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *Resolver) lastRun(filter dbpkg.RunFilter) (*dbpkg.TestInstance, error) {
+	page, err := r.db.ListRuns(filter, 1, "")
+	if err != nil || len(page.Runs) == 0 {
+		return nil, err
+	}
+	return &page.Runs[0], nil
+}
+
 type testInstanceResolver struct{ *Resolver }
 
 func (r *testInstanceResolver) ID(ctx context.Context, obj *dbpkg.TestInstance) (string, error) {
@@ -54,3 +773,16 @@ func (r *testInstanceResolver) EndAt(ctx context.Context, obj *dbpkg.TestInstanc
 func (r *testInstanceResolver) FailCause(ctx context.Context, obj *dbpkg.TestInstance) (*string, error) {
 	return &obj.FailCause, nil
 }
+func (r *testInstanceResolver) FailKind(ctx context.Context, obj *dbpkg.TestInstance) (*FailureKind, error) {
+	if obj.FailKind == "" {
+		return nil, nil
+	}
+	kind := FailureKind(strings.ToUpper(string(obj.FailKind)))
+	return &kind, nil
+}
+
+// dbFailureKind returns the kind of failures stored on the runs, which are the
+// values of the enum in lower case.
+func dbFailureKind(kind FailureKind) dbpkg.FailureKind {
+	return dbpkg.FailureKind(strings.ToLower(string(kind)))
+}
@@ -0,0 +1,278 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/iheanyi/simple-canary/internal/auth"
+	dbpkg "github.com/iheanyi/simple-canary/internal/db"
+	"github.com/iheanyi/simple-canary/internal/js"
+)
+
+// apiTest is a test as described by the REST API. Durations are in seconds.
+type apiTest struct {
+	Name      string       `json:"name"`
+	Canary    string       `json:"canary"`
+	Frequency float64      `json:"frequency"`
+	Timeout   float64      `json:"timeout"`
+	Status    string       `json:"status"`
+	LastRun   *apiRun      `json:"last_run"`
+	NextRunAt *time.Time   `json:"next_run_at"`
+	Pause     *dbpkg.Pause `json:"pause"`
+}
+
+// apiRun is a run as described by the REST API. The details of the run are
+// only given when it's asked for by its ID.
+type apiRun struct {
+	ID        string            `json:"id"`
+	Name      string            `json:"name"`
+	StartAt   time.Time         `json:"start_at"`
+	EndAt     time.Time         `json:"end_at"`
+	Pass      bool              `json:"pass"`
+	FailCause string            `json:"fail_cause"`
+	FailKind  dbpkg.FailureKind `json:"fail_kind"`
+	Logs      []dbpkg.LogEntry  `json:"logs,omitempty"`
+	Steps     []dbpkg.Step      `json:"steps,omitempty"`
+	HTTPTrips []dbpkg.HTTPTrip  `json:"http_trips,omitempty"`
+}
+
+func newAPIRun(test *dbpkg.TestInstance, details bool) *apiRun {
+	run := &apiRun{
+		ID:        test.TestID,
+		Name:      test.TestName,
+		StartAt:   test.StartAt,
+		EndAt:     test.EndAt,
+		Pass:      test.Pass,
+		FailCause: test.FailCause,
+		FailKind:  test.FailKind,
+	}
+	if details {
+		run.Logs = test.Logs
+		run.Steps = test.Steps
+		run.HTTPTrips = test.HTTPTrips
+	}
+	return run
+}
+
+// apiError is the body of every error response of the REST API.
+type apiError struct {
+	Error string `json:"error"`
+}
+
+// restAPI sets up the REST API on the router. Triggering a run requires the
+// admin role, and the rest requires the read role.
+func (app *App) restAPI(r *mux.Router) {
+	r.Handle("/tests", apiRequire(auth.Read, app.apiListTests)).Methods("GET")
+	r.Handle("/tests/{name}", apiRequire(auth.Read, app.apiGetTest)).Methods("GET")
+	r.Handle("/tests/{name}/runs", apiRequire(auth.Read, app.apiListRuns)).Methods("GET")
+	r.Handle("/tests/{name}/run", apiRequire(auth.Admin, app.apiRunTest)).Methods("POST")
+	r.Handle("/runs/{id}", apiRequire(auth.Read, app.apiGetRun)).Methods("GET")
+	r.PathPrefix("/").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeAPIError(w, http.StatusNotFound, fmt.Sprintf("no such endpoint: %s %s", r.Method, r.URL.Path))
+	})
+}
+
+// apiRequire is like auth.Require, with the error bodies of the REST API.
+func apiRequire(role auth.Role, h http.HandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		p := auth.FromContext(r.Context())
+		switch {
+		case p.Role >= role:
+			h(w, r)
+		case p.Anonymous:
+			w.Header().Add("WWW-Authenticate", `Bearer realm="canary"`)
+			w.Header().Add("WWW-Authenticate", `Basic realm="canary"`)
+			writeAPIError(w, http.StatusUnauthorized, "unauthorized")
+		default:
+			writeAPIError(w, http.StatusForbidden, fmt.Sprintf("requires the %s role", role))
+		}
+	})
+}
+
+func (app *App) apiListTests(w http.ResponseWriter, r *http.Request) {
+	tests := make([]*apiTest, 0, len(app.res.configs))
+	for _, cfg := range app.res.configs {
+		test, err := app.apiTest(cfg)
+		if err != nil {
+			app.apiInternalError(w, err)
+			return
+		}
+		tests = append(tests, test)
+	}
+	writeAPI(w, http.StatusOK, tests)
+}
+
+func (app *App) apiGetTest(w http.ResponseWriter, r *http.Request) {
+	cfg, ok := app.apiTestConfig(w, r)
+	if !ok {
+		return
+	}
+	test, err := app.apiTest(cfg)
+	if err != nil {
+		app.apiInternalError(w, err)
+		return
+	}
+	writeAPI(w, http.StatusOK, test)
+}
+
+// apiListRuns lists the runs of a test, most recent first. They can be
+// filtered with the pass, fail_kind, from and to parameters, and paged through with the
+// first and after parameters.
+func (app *App) apiListRuns(w http.ResponseWriter, r *http.Request) {
+	cfg, ok := app.apiTestConfig(w, r)
+	if !ok {
+		return
+	}
+
+	q := r.URL.Query()
+	filter := dbpkg.RunFilter{Name: cfg.Name}
+	first := 50
+	params := map[string]func(string) error{
+		"pass": func(v string) error {
+			pass, err := strconv.ParseBool(v)
+			filter.Pass = &pass
+			return err
+		},
+		"fail_kind": func(v string) (err error) {
+			filter.FailKind, err = dbpkg.ParseFailureKind(v)
+			return err
+		},
+		"from": func(v string) (err error) {
+			filter.From, err = time.Parse(time.RFC3339, v)
+			return err
+		},
+		"to": func(v string) (err error) {
+			filter.To, err = time.Parse(time.RFC3339, v)
+			return err
+		},
+		"first": func(v string) (err error) {
+			if first, err = strconv.Atoi(v); err != nil {
+				return err
+			}
+			if first < 1 || first > maxRunsPage {
+				return fmt.Errorf("must be between 1 and %d", maxRunsPage)
+			}
+			return nil
+		},
+	}
+	for name, parse := range params {
+		if v := q.Get(name); v != "" {
+			if err := parse(v); err != nil {
+				writeAPIError(w, http.StatusBadRequest, fmt.Sprintf("invalid %s: %v", name, err))
+				return
+			}
+		}
+	}
+
+	page, err := app.db.ListRuns(filter, first, q.Get("after"))
+	if err != nil {
+		if err == dbpkg.ErrInvalidCursor {
+			writeAPIError(w, http.StatusBadRequest, err.Error())
+		} else {
+			app.apiInternalError(w, err)
+		}
+		return
+	}
+	resp := struct {
+		Runs       []*apiRun `json:"runs"`
+		TotalCount int       `json:"total_count"`
+		NextCursor *string   `json:"next_cursor"`
+	}{
+		Runs:       make([]*apiRun, 0, len(page.Runs)),
+		TotalCount: page.TotalCount,
+	}
+	for i := range page.Runs {
+		resp.Runs = append(resp.Runs, newAPIRun(&page.Runs[i], false))
+	}
+	if page.HasNextPage {
+		cursor := dbpkg.RunCursor(&page.Runs[len(page.Runs)-1])
+		resp.NextCursor = &cursor
+	}
+	writeAPI(w, http.StatusOK, resp)
+}
+
+// apiRunTest has a test run right away, even if it's paused.
+func (app *App) apiRunTest(w http.ResponseWriter, r *http.Request) {
+	cfg, ok := app.apiTestConfig(w, r)
+	if !ok {
+		return
+	}
+	if !app.res.sched.RunNow(cfg.Name) {
+		writeAPIError(w, http.StatusConflict, fmt.Sprintf("test %q isn't scheduled yet", cfg.Name))
+		return
+	}
+	app.l.WithField("test.name", cfg.Name).Info("test run requested")
+	test, err := app.apiTest(cfg)
+	if err != nil {
+		app.apiInternalError(w, err)
+		return
+	}
+	writeAPI(w, http.StatusAccepted, test)
+}
+
+func (app *App) apiGetRun(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	test, err := app.db.FindTestByID(id)
+	if err != nil {
+		writeAPIError(w, http.StatusNotFound, fmt.Sprintf("no run with ID %q", id))
+		return
+	}
+	writeAPI(w, http.StatusOK, newAPIRun(test, true))
+}
+
+// apiTestConfig finds the test named in the path, or responds that there's
+// no such test.
+func (app *App) apiTestConfig(w http.ResponseWriter, r *http.Request) (*js.TestConfig, bool) {
+	name := mux.Vars(r)["name"]
+	cfg, ok := app.res.tests[name]
+	if !ok {
+		writeAPIError(w, http.StatusNotFound, fmt.Sprintf("no test named %q", name))
+	}
+	return cfg, ok
+}
+
+func (app *App) apiTest(cfg *js.TestConfig) (*apiTest, error) {
+	test := &apiTest{
+		Name:      cfg.Name,
+		Canary:    app.res.canary.Name,
+		Frequency: cfg.Frequency.Seconds(),
+		Timeout:   cfg.Timeout.Seconds(),
+	}
+	status, err := app.res.status(cfg.Name)
+	if err != nil {
+		return nil, err
+	}
+	test.Status = strings.ToLower(string(status))
+
+	last, err := app.res.lastRun(dbpkg.RunFilter{Name: cfg.Name})
+	if err != nil {
+		return nil, err
+	} else if last != nil {
+		test.LastRun = newAPIRun(last, false)
+	}
+	if next, ok := app.res.sched.NextRun(cfg.Name); ok {
+		test.NextRunAt = &next
+	}
+	test.Pause, err = app.res.activePause(cfg.Name)
+	return test, err
+}
+
+func (app *App) apiInternalError(w http.ResponseWriter, err error) {
+	app.l.WithError(err).Error("can't serve API request")
+	writeAPIError(w, http.StatusInternalServerError, "internal error")
+}
+
+func writeAPIError(w http.ResponseWriter, code int, msg string) {
+	writeAPI(w, code, apiError{Error: msg})
+}
+
+func writeAPI(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(v)
+}
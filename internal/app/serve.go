@@ -1,29 +1,72 @@
 package app
 
 import (
+	"net/http"
+
 	"github.com/99designs/gqlgen/handler"
 	"github.com/gorilla/mux"
+	"github.com/iheanyi/simple-canary/internal/auth"
 	"github.com/iheanyi/simple-canary/internal/db"
+	"github.com/iheanyi/simple-canary/internal/events"
+	"github.com/iheanyi/simple-canary/internal/js"
+	"github.com/iheanyi/simple-canary/internal/js/canary"
+	"github.com/iheanyi/simple-canary/internal/scheduler"
 	"github.com/sirupsen/logrus"
 )
 
 // App is an instance of the dashboard for the canary.
 type App struct {
-	l  logrus.FieldLogger
-	db db.CanaryStore
+	l   logrus.FieldLogger
+	db  db.CanaryStore
+	res *Resolver
 }
 
-func New(db db.CanaryStore, r *mux.Router) *App {
+// New sets up the dashboard for the tests of the canary on the router. The
+// router must authenticate requests: seeing the dashboard requires the read
+// role, and the admin endpoints and mutations require the admin role.
+func New(
+	db db.CanaryStore,
+	bus *events.Bus,
+	sched *scheduler.Scheduler,
+	canaryCfg *canary.Config,
+	tests []*js.TestConfig,
+	r *mux.Router,
+) *App {
 	app := &App{
 		l:  logrus.WithField("component", "app"),
 		db: db,
 	}
 
-	r.Handle("/", handler.Playground("GraphQL Playground", "/query"))
-	r.Handle("/query", handler.GraphQL(NewExecutableSchema(Config{Resolvers: &Resolver{
-		db: db,
-	}})))
+	testsByName := make(map[string]*js.TestConfig, len(tests))
+	for _, cfg := range tests {
+		testsByName[cfg.Name] = cfg
+	}
+
+	app.res = &Resolver{
+		l:       app.l,
+		db:      db,
+		bus:     bus,
+		sched:   sched,
+		canary:  canaryCfg,
+		configs: tests,
+		tests:   testsByName,
+	}
+
+	r.Handle("/", auth.Require(auth.Read, handler.Playground("GraphQL Playground", "/query")))
+	r.Handle("/query", auth.Require(auth.Read, handler.GraphQL(NewExecutableSchema(Config{Resolvers: app.res}))))
+	r.Handle("/status", requireRead(app.status)).Methods("GET")
+	r.Handle("/status/runs/{id}", requireRead(app.runStatus)).Methods("GET")
+	r.Handle("/status/tests/{name}/alerts", requireRead(app.alertStatus)).Methods("GET")
+	app.restAPI(r.PathPrefix("/api/v1").Subrouter())
+	app.probes(r)
+	r.Handle("/badge.svg", requireRead(app.canaryBadge)).Methods("GET", "HEAD")
+	r.Handle("/badge/{test}.svg", requireRead(app.testBadge)).Methods("GET", "HEAD")
+	r.Handle("/admin/backup", auth.Require(auth.Admin, http.HandlerFunc(app.backup))).Methods("GET")
 
 	// TODO: Setup GraphQL server here please.
 	return app
 }
+
+func requireRead(h http.HandlerFunc) http.Handler {
+	return auth.Require(auth.Read, h)
+}
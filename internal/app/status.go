@@ -0,0 +1,237 @@
+package app
+
+import (
+	"fmt"
+	"html/template"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	dbpkg "github.com/iheanyi/simple-canary/internal/db"
+)
+
+// sparklineRuns is how many of the latest runs of each test are shown on the
+// status page.
+const sparklineRuns = 30
+
+type testStatus struct {
+	Name      string
+	Status    TestStatus
+	LastRun   *dbpkg.TestInstance
+	Sparkline []dbpkg.TestInstance
+	Uptime24h *float64
+	Uptime7d  *float64
+}
+
+// status renders a page telling how every test is doing.
+func (app *App) status(w http.ResponseWriter, r *http.Request) {
+	now := time.Now()
+	data := struct {
+		Canary string
+		Now    time.Time
+		Tests  []testStatus
+	}{Canary: app.res.canary.Name, Now: now}
+
+	for _, cfg := range app.res.configs {
+		ts := testStatus{Name: cfg.Name}
+		var err error
+		if ts.Status, err = app.res.status(cfg.Name); err != nil {
+			app.statusError(w, err)
+			return
+		}
+
+		page, err := app.db.ListRuns(dbpkg.RunFilter{Name: cfg.Name}, sparklineRuns, "")
+		if err != nil {
+			app.statusError(w, err)
+			return
+		}
+		if len(page.Runs) > 0 {
+			ts.LastRun = &page.Runs[0]
+		}
+		// Oldest first, so the sparkline reads from left to right.
+		for i := len(page.Runs) - 1; i >= 0; i-- {
+			ts.Sparkline = append(ts.Sparkline, page.Runs[i])
+		}
+
+		rollups, err := app.db.ListRollups(cfg.Name, dbpkg.Hourly, now.Add(-7*24*time.Hour), now.Add(time.Hour))
+		if err != nil {
+			app.statusError(w, err)
+			return
+		}
+		ts.Uptime24h = uptime(rollups, dbpkg.Hourly.Truncate(now.Add(-24*time.Hour)))
+		ts.Uptime7d = uptime(rollups, dbpkg.Hourly.Truncate(now.Add(-7*24*time.Hour)))
+
+		data.Tests = append(data.Tests, ts)
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := statusTmpl.ExecuteTemplate(w, "status", data); err != nil {
+		app.l.WithError(err).Error("can't render status page")
+	}
+}
+
+// runStatus renders a page with the details of a run.
+func (app *App) runStatus(w http.ResponseWriter, r *http.Request) {
+	test, err := app.db.FindTestByID(mux.Vars(r)["id"])
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := statusTmpl.ExecuteTemplate(w, "run", test); err != nil {
+		app.l.WithError(err).Error("can't render run page")
+	}
+}
+
+// alertStatus renders a page with the alert history of a test over the last
+// week.
+func (app *App) alertStatus(w http.ResponseWriter, r *http.Request) {
+	name := mux.Vars(r)["name"]
+	if _, ok := app.res.tests[name]; !ok {
+		http.NotFound(w, r)
+		return
+	}
+	now := time.Now()
+	history, err := app.res.alertHistory(name, now.Add(-7*24*time.Hour), now)
+	if err != nil {
+		app.statusError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := statusTmpl.ExecuteTemplate(w, "alerts", history); err != nil {
+		app.l.WithError(err).Error("can't render alerts page")
+	}
+}
+
+func (app *App) statusError(w http.ResponseWriter, err error) {
+	app.l.WithError(err).Error("can't gather the status of tests")
+	http.Error(w, "can't gather the status of tests", http.StatusInternalServerError)
+}
+
+// uptime is the percentage of the runs that passed in the rollups starting
+// since the given time, or nil if there were none.
+func uptime(rollups []dbpkg.Rollup, since time.Time) *float64 {
+	var runs, passes int
+	for _, r := range rollups {
+		if !r.StartAt.Before(since) {
+			runs += r.Runs
+			passes += r.Passes
+		}
+	}
+	if runs == 0 {
+		return nil
+	}
+	pct := 100 * float64(passes) / float64(runs)
+	return &pct
+}
+
+var statusTmpl = template.Must(template.New("").Funcs(template.FuncMap{
+	"when": func(t time.Time) string {
+		return t.UTC().Format("2006-01-02 15:04:05 MST")
+	},
+	"percent": func(pct *float64) string {
+		if pct == nil {
+			return "-"
+		}
+		return fmt.Sprintf("%.2f%%", *pct)
+	},
+	"took": func(test *dbpkg.TestInstance) time.Duration {
+		return test.EndAt.Sub(test.StartAt).Round(time.Millisecond)
+	},
+	"round": func(d time.Duration) time.Duration {
+		return d.Round(time.Millisecond)
+	},
+}).Parse(`
+{{define "head"}}<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.}}</title>
+<style>
+body { font-family: sans-serif; margin: 2em; color: #222; }
+table { border-collapse: collapse; }
+th, td { text-align: left; padding: .3em .8em; border-bottom: 1px solid #ddd; vertical-align: top; }
+.PASSING, .pass { color: #1a7f37; }
+.FAILING, .fail { color: #cf222e; }
+.RUNNING { color: #0969da; }
+.PAUSED, .UNKNOWN { color: #777; }
+.spark a { display: inline-block; width: 6px; height: 16px; margin-right: 1px; }
+.spark a.pass { background: #2da44e; }
+.spark a.fail { background: #cf222e; }
+pre { background: #f6f8fa; padding: .5em; white-space: pre-wrap; }
+</style>
+</head>
+<body>
+{{end}}
+
+{{define "status"}}{{template "head" .Canary}}
+<h1>{{.Canary}}</h1>
+<p>As of {{when .Now}}.</p>
+<table>
+<tr><th>Test</th><th>State</th><th>Last run</th><th>Recent runs</th><th>Uptime 24h</th><th>Uptime 7d</th></tr>
+{{range .Tests}}
+<tr>
+<td><a href="/status/tests/{{.Name}}/alerts">{{.Name}}</a></td>
+<td class="{{.Status}}">{{.Status}}</td>
+<td>{{with .LastRun}}<a href="/status/runs/{{.TestID}}">{{when .StartAt}}</a>{{else}}never{{end}}</td>
+<td class="spark">{{range .Sparkline}}<a href="/status/runs/{{.TestID}}" class="{{if .Pass}}pass{{else}}fail{{end}}" title="{{when .StartAt}}"></a>{{end}}</td>
+<td>{{percent .Uptime24h}}</td>
+<td>{{percent .Uptime7d}}</td>
+</tr>
+{{end}}
+</table>
+</body>
+</html>
+{{end}}
+
+{{define "alerts"}}{{template "head" .Name}}
+<p><a href="/status">&larr; status</a></p>
+<h1>Alerts of {{.Name}}</h1>
+<p>From {{when .From}} to {{when .To}}.</p>
+<h2>Transitions</h2>
+{{if .Transitions}}<table>
+<tr><th>Time</th><th>State</th><th>Run</th></tr>
+{{range .Transitions}}<tr><td>{{when .At}}</td><td>{{if .Firing}}<span class="fail">firing</span>{{else}}<span class="pass">ok</span>{{end}}{{if .Flapping}}, flapping{{end}}</td><td>{{if .RunID}}<a href="/status/runs/{{.RunID}}">{{.RunID}}</a>{{end}}</td></tr>
+{{end}}</table>{{else}}<p>The alert didn't change.</p>{{end}}
+<h2>Notifications</h2>
+{{if .Deliveries}}<table>
+<tr><th>Time</th><th>Notifier</th><th>Attempt</th><th>Status</th><th>Took</th><th>Outcome</th></tr>
+{{range .Deliveries}}<tr><td>{{when .At}}</td><td>{{.Notifier}}</td><td>{{if .Attempt}}{{.Attempt}}{{end}}</td><td>{{if .StatusCode}}{{.StatusCode}}{{end}}</td><td>{{if .Attempt}}{{round .Latency}}{{end}}</td>
+<td>{{if eq .SilencedBy "digest"}}left to the digest{{else if .SilencedBy}}silenced by {{.SilencedBy}}{{else if .Error}}<span class="fail">{{.Error}}</span>{{if .Final}}, gave up{{end}}{{else}}<span class="pass">delivered</span>{{end}}</td></tr>
+{{end}}</table>{{else}}<p>Nobody was notified.</p>{{end}}
+</body>
+</html>
+{{end}}
+
+{{define "run"}}{{template "head" .TestName}}
+<p><a href="/status">&larr; status</a></p>
+<h1>{{.TestName}}</h1>
+<table>
+<tr><th>ID</th><td>{{.TestID}}</td></tr>
+<tr><th>Result</th><td>{{if .Pass}}<span class="pass">passed</span>{{else}}<span class="fail">failed</span>{{if .FailKind}} ({{.FailKind}}){{end}}{{end}}</td></tr>
+<tr><th>Started</th><td>{{when .StartAt}}</td></tr>
+<tr><th>Took</th><td>{{took .}}</td></tr>
+</table>
+{{if .FailCause}}<h2>Fail cause</h2>
+<pre>{{.FailCause}}</pre>{{end}}
+{{if .Steps}}<h2>Steps</h2>
+<table>
+<tr><th>Step</th><th>Result</th><th>Started</th><th>Fail cause</th></tr>
+{{range .Steps}}<tr><td>{{.Name}}</td><td>{{if .Pass}}<span class="pass">passed</span>{{else}}<span class="fail">failed</span>{{end}}</td><td>{{when .StartAt}}</td><td>{{.FailCause}}</td></tr>
+{{end}}</table>{{end}}
+{{if .HTTPTrips}}<h2>HTTP requests</h2>
+<table>
+<tr><th>Request</th><th>Status</th><th>Took</th><th>Error</th></tr>
+{{range .HTTPTrips}}<tr><td>{{.Method}} {{.URL}}</td><td>{{if .StatusCode}}{{.StatusCode}}{{end}}</td><td>{{.Duration}}</td><td>{{.Error}}</td></tr>
+{{end}}</table>{{end}}
+<h2>Logs</h2>
+{{if .Logs}}<table>
+<tr><th>Time</th><th>Level</th><th>Message</th><th>Fields</th></tr>
+{{range .Logs}}<tr><td>{{when .Time}}</td><td>{{.Level}}</td><td>{{.Message}}</td><td>{{range $k, $v := .Fields}}{{$k}}={{$v}} {{end}}</td></tr>
+{{end}}</table>{{else}}<p>Nothing was logged.</p>{{end}}
+</body>
+</html>
+{{end}}
+`))
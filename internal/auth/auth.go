@@ -0,0 +1,143 @@
+// Package auth tells who is making requests to the canary, and what they're
+// allowed to do.
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+)
+
+// Role is what a principal is allowed to do. Each role is allowed what the
+// lesser roles are.
+type Role int
+
+// The roles, from least to most allowed.
+const (
+	// None isn't allowed anything, unless it's public.
+	None Role = iota
+	// Read can see the tests and their runs.
+	Read
+	// Admin can also trigger, pause and resume tests, and backup the
+	// database.
+	Admin
+)
+
+var roleNames = map[Role]string{
+	None:  "none",
+	Read:  "read",
+	Admin: "admin",
+}
+
+func (r Role) String() string {
+	return roleNames[r]
+}
+
+// UnmarshalText parses a role from its name.
+func (r *Role) UnmarshalText(text []byte) error {
+	for role, name := range roleNames {
+		if name == string(text) {
+			*r = role
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown role: %q", text)
+}
+
+// A Principal is who made a request.
+type Principal struct {
+	// Name identifies the principal. For anonymous requests, it's the
+	// address they came from.
+	Name      string
+	Role      Role
+	Anonymous bool
+}
+
+// An Authenticator tells who made a request from the credentials it bears. It
+// returns nil if there are no credentials it knows how to check, and
+// ErrBadCredentials if they're wrong.
+type Authenticator interface {
+	Authenticate(r *http.Request) (*Principal, error)
+}
+
+// ErrBadCredentials is returned by authenticators when the credentials of a
+// request don't check out.
+var ErrBadCredentials = errors.New("bad credentials")
+
+// A Guard authenticates requests using a series of authenticators, the first
+// to recognize the credentials of a request having the final word.
+type Guard struct {
+	anonymous      Role
+	authenticators []Authenticator
+}
+
+// NewGuard creates a guard that gives the anonymous role to the requests that
+// bear no credentials.
+func NewGuard(anonymous Role, authenticators ...Authenticator) *Guard {
+	return &Guard{anonymous: anonymous, authenticators: authenticators}
+}
+
+// Authenticate is a middleware that has the principal of each request
+// available in its context, and rejects those with bad credentials.
+func (g *Guard) Authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var p *Principal
+		for _, a := range g.authenticators {
+			var err error
+			if p, err = a.Authenticate(r); err != nil {
+				unauthorized(w)
+				return
+			} else if p != nil {
+				break
+			}
+		}
+		if p == nil {
+			p = &Principal{Name: r.RemoteAddr, Role: g.anonymous, Anonymous: true}
+			if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+				p.Name = host
+			}
+		}
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), principalKey{}, p)))
+	})
+}
+
+// Require only lets through the requests of principals that have at least the
+// given role.
+func Require(role Role, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		p := FromContext(r.Context())
+		switch {
+		case p.Role >= role:
+			next.ServeHTTP(w, r)
+		case p.Anonymous:
+			unauthorized(w)
+		default:
+			http.Error(w, "forbidden", http.StatusForbidden)
+		}
+	})
+}
+
+func unauthorized(w http.ResponseWriter) {
+	w.Header().Add("WWW-Authenticate", `Bearer realm="canary"`)
+	w.Header().Add("WWW-Authenticate", `Basic realm="canary"`)
+	http.Error(w, "unauthorized", http.StatusUnauthorized)
+}
+
+type principalKey struct{}
+
+// FromContext returns the principal of the request of the context. Without
+// one, it's an anonymous principal that isn't allowed anything.
+func FromContext(ctx context.Context) *Principal {
+	if p, ok := ctx.Value(principalKey{}).(*Principal); ok {
+		return p
+	}
+	return &Principal{Role: None, Anonymous: true}
+}
+
+// Allowed tells whether the principal of the request of the context has at
+// least the given role.
+func Allowed(ctx context.Context, role Role) bool {
+	return FromContext(ctx).Role >= role
+}
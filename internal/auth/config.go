@@ -0,0 +1,69 @@
+package auth
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+)
+
+// Config is how access to the canary is controlled. It's read as JSON, from a
+// file or from the settings of the canary, like:
+//
+//	{
+//	  "anonymous": "none",
+//	  "public_metrics": true,
+//	  "tokens": [{"name": "ci", "token": "s3cret", "role": "admin"}],
+//	  "users": [{"name": "ops", "password": "hunter2", "role": "read"}]
+//	}
+type Config struct {
+	// Anonymous is the role of requests without credentials. It defaults
+	// to read when there are no tokens or users, and to none otherwise.
+	Anonymous *Role `json:"anonymous"`
+	// PublicMetrics leaves the Prometheus metrics open to anyone.
+	PublicMetrics bool    `json:"public_metrics"`
+	Tokens        []Token `json:"tokens"`
+	Users         []User  `json:"users"`
+}
+
+// Parse reads a config from JSON.
+func Parse(data []byte) (*Config, error) {
+	cfg := new(Config)
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("can't parse auth config: %v", err)
+	}
+	for _, t := range cfg.Tokens {
+		if t.Token == "" {
+			return nil, fmt.Errorf("token %q is empty", t.Name)
+		}
+	}
+	for _, u := range cfg.Users {
+		if u.Name == "" || u.Password == "" {
+			return nil, fmt.Errorf("user %q needs both a name and a password", u.Name)
+		}
+	}
+	return cfg, nil
+}
+
+// Load reads a config from a JSON file.
+func Load(path string) (*Config, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data)
+}
+
+// Guard creates the guard enforcing the config. The extra tokens are accepted
+// along with those of the config, but don't count towards the default role of
+// anonymous requests.
+func (cfg *Config) Guard(extra ...Token) *Guard {
+	anonymous := Read
+	if len(cfg.Tokens) > 0 || len(cfg.Users) > 0 {
+		anonymous = None
+	}
+	if cfg.Anonymous != nil {
+		anonymous = *cfg.Anonymous
+	}
+	tokens := append(append([]Token{}, cfg.Tokens...), extra...)
+	return NewGuard(anonymous, Tokens(tokens), Basic(cfg.Users))
+}
@@ -0,0 +1,63 @@
+package auth
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+)
+
+// A Token is a static bearer token, and the principal it authenticates.
+type Token struct {
+	Name  string `json:"name"`
+	Token string `json:"token"`
+	Role  Role   `json:"role"`
+}
+
+// Tokens authenticates the requests bearing one of the tokens.
+func Tokens(tokens []Token) Authenticator {
+	return bearerTokens(tokens)
+}
+
+type bearerTokens []Token
+
+func (tokens bearerTokens) Authenticate(r *http.Request) (*Principal, error) {
+	h := r.Header.Get("Authorization")
+	if !strings.HasPrefix(h, "Bearer ") {
+		return nil, nil
+	}
+	given := []byte(strings.TrimPrefix(h, "Bearer "))
+	for _, t := range tokens {
+		if subtle.ConstantTimeCompare(given, []byte(t.Token)) == 1 {
+			return &Principal{Name: t.Name, Role: t.Role}, nil
+		}
+	}
+	return nil, ErrBadCredentials
+}
+
+// A User authenticates with a name and password, using HTTP basic auth.
+type User struct {
+	Name     string `json:"name"`
+	Password string `json:"password"`
+	Role     Role   `json:"role"`
+}
+
+// Basic authenticates the requests bearing the basic auth credentials of one
+// of the users.
+func Basic(users []User) Authenticator {
+	return basicUsers(users)
+}
+
+type basicUsers []User
+
+func (users basicUsers) Authenticate(r *http.Request) (*Principal, error) {
+	name, password, ok := r.BasicAuth()
+	if !ok {
+		return nil, nil
+	}
+	for _, u := range users {
+		if u.Name == name && subtle.ConstantTimeCompare([]byte(password), []byte(u.Password)) == 1 {
+			return &Principal{Name: u.Name, Role: u.Role}, nil
+		}
+	}
+	return nil, ErrBadCredentials
+}
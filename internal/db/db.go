@@ -1,12 +1,68 @@
 package db
 
-import "time"
+import (
+	"fmt"
+	"io"
+	"time"
+)
 
 type CanaryStore interface {
 	StartTest(id string, testName string, startTime time.Time) (*TestInstance, error)
 	EndTest(test *TestInstance, failure error, endAt time.Time) error
+	// ImportTest saves a test that has already ended, unless a test with the
+	// same ID is already stored. It reports whether the test was saved.
+	ImportTest(test *TestInstance) (bool, error)
 	ListTests() ([]TestInstance, error)
+	// ListRuns returns the runs selected by the filter, most recently
+	// started first. The page holds at most first runs, or all of them if
+	// first is 0, starting after the run located by the cursor, if any.
+	ListRuns(filter RunFilter, first int, after string) (*RunPage, error)
 	ListOngoingTests() ([]TestInstance, error)
 	FindTestByID(id string) (*TestInstance, error)
+	// ListRollups returns the rollups of the named test at the given
+	// granularity, for the periods starting between from and to.
+	ListRollups(name string, g Granularity, from, to time.Time) ([]Rollup, error)
+	// PauseTest keeps track of a pause of a test, replacing any previous
+	// one, until ResumeTest is called for that test.
+	PauseTest(pause Pause) error
+	ResumeTest(name string) error
+	ListPauses() ([]Pause, error)
+	// PutAlertState saves the alert state of a test, replacing any
+	// previous one.
+	PutAlertState(state AlertState) error
+	ListAlertStates() ([]AlertState, error)
+	// PutSilence saves the silence, replacing any previous one with the
+	// same ID.
+	PutSilence(silence Silence) error
+	ListSilences() ([]Silence, error)
+	// AddAlertTransition and AddDeliveryAttempt keep the history of the
+	// alerts of the tests, which the List methods return for the named
+	// test between from and to, oldest first.
+	AddAlertTransition(t AlertTransition) error
+	AddDeliveryAttempt(a DeliveryAttempt) error
+	ListAlertTransitions(name string, from, to time.Time) ([]AlertTransition, error)
+	ListDeliveryAttempts(name string, from, to time.Time) ([]DeliveryAttempt, error)
+	// Prune deletes the tests that started before runsBefore, along with
+	// the alert history, and the rollups of the periods that started before
+	// rollupsBefore.
+	Prune(runsBefore, rollupsBefore time.Time) error
+	// Backup writes a consistent snapshot of the database to w, while it
+	// remains usable by others.
+	Backup(w io.Writer) (int64, error)
+	// Ping checks that the database can be written to.
+	Ping() error
 	Close() error
 }
+
+// Open opens the store kept in the file at path, using the given driver. The
+// drivers are "bolt" and "sqlite".
+func Open(driver, path string) (CanaryStore, error) {
+	switch driver {
+	case "bolt":
+		return NewBoltStore(path)
+	case "sqlite":
+		return NewSQLiteStore(path)
+	default:
+		return nil, fmt.Errorf("unknown database driver: %q", driver)
+	}
+}
@@ -1,11 +1,13 @@
 package db
 
 import (
+	"bytes"
 	"context"
+	"encoding/binary"
 	"encoding/json"
 	"fmt"
+	"io"
 	"sort"
-	"sync"
 	"time"
 
 	"github.com/boltdb/bolt"
@@ -14,14 +16,26 @@ import (
 var _ CanaryStore = (*boltStore)(nil)
 
 type boltStore struct {
-	db        *bolt.DB
-	ongoingMu sync.RWMutex
-
-	ongoing map[string]TestInstance
+	db      *bolt.DB
+	ongoing *ongoingTests
 	cancel  context.CancelFunc
 }
 
-var testsBucket = []byte("tests")
+var (
+	testsBucket   = []byte("tests")
+	rollupsBucket = []byte("rollups")
+	// The runs_by_start and runs_by_name buckets index the tests by the
+	// time at which they started, and by name then time.
+	runsByStartBucket = []byte("runs_by_start")
+	runsByNameBucket  = []byte("runs_by_name")
+	pausesBucket      = []byte("pauses")
+	alertsBucket      = []byte("alerts")
+	silencesBucket    = []byte("silences")
+	// The alert_transitions and delivery_attempts buckets are keyed by test
+	// name then time.
+	transitionsBucket = []byte("alert_transitions")
+	attemptsBucket    = []byte("delivery_attempts")
+)
 
 // NewBoltStore creates a new instance of the BoltStore
 func NewBoltStore(path string) (CanaryStore, error) {
@@ -38,7 +52,26 @@ func NewBoltStore(path string) (CanaryStore, error) {
 	return &boltStore{
 		cancel:  cancel,
 		db:      db,
-		ongoing: make(map[string]TestInstance),
+		ongoing: newOngoingTests(),
+	}, nil
+}
+
+// NewReadOnlyBoltStore opens an existing Bolt database for reading. It gives
+// up after a second if another process has the database open for writing.
+func NewReadOnlyBoltStore(path string) (CanaryStore, error) {
+	db, err := bolt.Open(path, 0600, &bolt.Options{
+		ReadOnly: true,
+		Timeout:  time.Second,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	_, cancel := context.WithCancel(context.TODO())
+	return &boltStore{
+		cancel:  cancel,
+		db:      db,
+		ongoing: newOngoingTests(),
 	}, nil
 }
 
@@ -51,9 +84,7 @@ func (db *boltStore) StartTest(id string, testName string, startTime time.Time)
 	}
 
 	// keep in memory until its finished
-	db.ongoingMu.Lock()
-	db.ongoing[id] = *test
-	db.ongoingMu.Unlock()
+	db.ongoing.start(*test)
 
 	// TODO: Couldn't we store an in-progress version of said test in the
 	// database? Hm.
@@ -62,23 +93,46 @@ func (db *boltStore) StartTest(id string, testName string, startTime time.Time)
 
 // EndTest marks a test as ended with it's log as well.
 func (db *boltStore) EndTest(test *TestInstance, failure error, endAt time.Time) error {
-	db.ongoingMu.Lock()
-	defer db.ongoingMu.Unlock()
-	t, ok := db.ongoing[test.TestID]
+	t, ok := db.ongoing.end(test.TestID)
 	if !ok {
 		return fmt.Errorf("test with ID does not exist: %q", test.TestID)
 	}
 
-	delete(db.ongoing, test.TestID)
 	t.Pass = failure == nil
 	if failure != nil {
 		t.FailCause = failure.Error()
+		t.FailKind = test.FailKind
 	}
 	t.EndAt = endAt
+	t.Logs = test.Logs
+	t.Steps = test.Steps
+	t.HTTPTrips = test.HTTPTrips
 
 	return insertTest(db.db, &t)
 }
 
+// ImportTest saves a test that has ended, unless its ID is already known.
+func (db *boltStore) ImportTest(test *TestInstance) (bool, error) {
+	var saved bool
+	err := db.db.Update(func(tx *bolt.Tx) error {
+		if tx.Bucket(testsBucket).Get([]byte(test.TestID)) != nil {
+			return nil
+		}
+		if err := putTest(tx, test); err != nil {
+			return err
+		}
+		if err := addToRollups(tx, test); err != nil {
+			return err
+		}
+		saved = true
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return saved, nil
+}
+
 func (db *boltStore) ListTests() ([]TestInstance, error) {
 	tests := make([]TestInstance, 0)
 	err := db.db.View(func(tx *bolt.Tx) error {
@@ -107,6 +161,9 @@ func (db *boltStore) FindTestByID(id string) (*TestInstance, error) {
 	err := db.db.View(func(tx *bolt.Tx) error {
 		b := tx.Bucket(testsBucket)
 		v := b.Get([]byte(id))
+		if v == nil {
+			return fmt.Errorf("test with ID does not exist: %q", id)
+		}
 
 		err := json.Unmarshal(v, test)
 		if err != nil {
@@ -122,16 +179,375 @@ func (db *boltStore) FindTestByID(id string) (*TestInstance, error) {
 // ListOngoingTests returns a list of ongoing tests. A test is ongong if it has
 // never be ended with EndTest.
 func (db *boltStore) ListOngoingTests() ([]TestInstance, error) {
-	var ongoing []TestInstance
-	func() {
-		db.ongoingMu.RLock()
-		defer db.ongoingMu.RUnlock()
-		for _, t := range db.ongoing {
-			ongoing = append(ongoing, t)
+	return db.ongoing.list(), nil
+}
+
+// ListRuns walks the index of the runs from the most recent one that may match
+// the filter, counting all those that do.
+func (db *boltStore) ListRuns(filter RunFilter, first int, after string) (*RunPage, error) {
+	var afterKey []byte
+	if after != "" {
+		startAt, id, err := parseRunCursor(after)
+		if err != nil {
+			return nil, err
+		}
+		afterKey = runKey(startAt, id)
+	}
+
+	page := &RunPage{Runs: make([]TestInstance, 0)}
+	err := db.db.View(func(tx *bolt.Tx) error {
+		index, prefix := tx.Bucket(runsByStartBucket), []byte(nil)
+		if filter.Name != "" {
+			index, prefix = tx.Bucket(runsByNameBucket), rollupPrefix(filter.Name)
+		}
+		if index == nil {
+			// Read-only opening of a database that predates the index.
+			return nil
+		}
+		tests := tx.Bucket(testsBucket)
+
+		c := index.Cursor()
+		var k []byte
+		switch {
+		case !filter.To.IsZero():
+			k = seekBefore(c, append(prefix, runKey(filter.To, "")...))
+		case filter.Name != "":
+			k = seekBefore(c, append([]byte(filter.Name), 1))
+		default:
+			k, _ = c.Last()
+		}
+		for ; k != nil && bytes.HasPrefix(k, prefix); k, _ = c.Prev() {
+			key := k[len(prefix):]
+			if !filter.From.IsZero() && runKeyTime(key).Before(filter.From) {
+				break
+			}
+			wanted := afterKey == nil || bytes.Compare(key, afterKey) < 0
+			wanted = wanted && (first <= 0 || len(page.Runs) < first)
+
+			var test TestInstance
+			if wanted || filter.Pass != nil || filter.FailKind != "" {
+				v := tests.Get(key[8:])
+				if v == nil {
+					continue
+				}
+				if err := json.Unmarshal(v, &test); err != nil {
+					return err
+				}
+				if filter.Pass != nil && test.Pass != *filter.Pass {
+					continue
+				}
+				if filter.FailKind != "" && test.FailKind != filter.FailKind {
+					continue
+				}
+			}
+
+			page.TotalCount++
+			if wanted {
+				page.Runs = append(page.Runs, test)
+			} else if afterKey == nil || bytes.Compare(key, afterKey) < 0 {
+				page.HasNextPage = true
+			}
+		}
+		return nil
+	})
+	return page, err
+}
+
+// seekBefore moves the cursor to the last key that sorts before the given
+// one.
+func seekBefore(c *bolt.Cursor, key []byte) []byte {
+	if k, _ := c.Seek(key); k == nil {
+		k, _ = c.Last()
+		return k
+	}
+	k, _ := c.Prev()
+	return k
+}
+
+// ListRollups returns the rollups of a test at a granularity, for the periods
+// starting between from and to.
+func (db *boltStore) ListRollups(name string, g Granularity, from, to time.Time) ([]Rollup, error) {
+	rollups := make([]Rollup, 0)
+	err := db.db.View(func(tx *bolt.Tx) error {
+		root := tx.Bucket(rollupsBucket)
+		if root == nil {
+			// Read-only opening of a database that predates rollups.
+			return nil
+		}
+		b := root.Bucket([]byte(g))
+		if b == nil {
+			return fmt.Errorf("unknown granularity: %q", g)
+		}
+
+		prefix := rollupPrefix(name)
+		c := b.Cursor()
+		for k, v := c.Seek(rollupKey(name, g.Truncate(from))); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
+			rollup := Rollup{}
+			if err := json.Unmarshal(v, &rollup); err != nil {
+				return err
+			}
+			if !rollup.StartAt.Before(to) {
+				break
+			}
+			rollups = append(rollups, rollup)
+		}
+		return nil
+	})
+	return rollups, err
+}
+
+// PauseTest saves the pause, keyed by the name of the test.
+func (db *boltStore) PauseTest(pause Pause) error {
+	return db.db.Update(func(tx *bolt.Tx) error {
+		buf, err := json.Marshal(pause)
+		if err != nil {
+			return err
+		}
+		return tx.Bucket(pausesBucket).Put([]byte(pause.TestName), buf)
+	})
+}
+
+// ResumeTest forgets about the pause of the test, if any.
+func (db *boltStore) ResumeTest(name string) error {
+	return db.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(pausesBucket).Delete([]byte(name))
+	})
+}
+
+func (db *boltStore) ListPauses() ([]Pause, error) {
+	pauses := make([]Pause, 0)
+	err := db.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(pausesBucket)
+		if b == nil {
+			// Read-only opening of a database that predates pauses.
+			return nil
+		}
+		return b.ForEach(func(k, v []byte) error {
+			pause := Pause{}
+			if err := json.Unmarshal(v, &pause); err != nil {
+				return err
+			}
+			pauses = append(pauses, pause)
+			return nil
+		})
+	})
+	return pauses, err
+}
+
+// PutAlertState saves the alert state, keyed by the name of the test.
+func (db *boltStore) PutAlertState(state AlertState) error {
+	return db.db.Update(func(tx *bolt.Tx) error {
+		buf, err := json.Marshal(state)
+		if err != nil {
+			return err
+		}
+		return tx.Bucket(alertsBucket).Put([]byte(state.TestName), buf)
+	})
+}
+
+func (db *boltStore) ListAlertStates() ([]AlertState, error) {
+	states := make([]AlertState, 0)
+	err := db.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(alertsBucket)
+		if b == nil {
+			// Read-only opening of a database that predates alerts.
+			return nil
+		}
+		return b.ForEach(func(k, v []byte) error {
+			state := AlertState{}
+			if err := json.Unmarshal(v, &state); err != nil {
+				return err
+			}
+			states = append(states, state)
+			return nil
+		})
+	})
+	return states, err
+}
+
+// PutSilence saves the silence, keyed by its ID.
+func (db *boltStore) PutSilence(silence Silence) error {
+	return db.db.Update(func(tx *bolt.Tx) error {
+		buf, err := json.Marshal(silence)
+		if err != nil {
+			return err
+		}
+		return tx.Bucket(silencesBucket).Put([]byte(silence.ID), buf)
+	})
+}
+
+func (db *boltStore) ListSilences() ([]Silence, error) {
+	silences := make([]Silence, 0)
+	err := db.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(silencesBucket)
+		if b == nil {
+			// Read-only opening of a database that predates silences.
+			return nil
+		}
+		return b.ForEach(func(k, v []byte) error {
+			silence := Silence{}
+			if err := json.Unmarshal(v, &silence); err != nil {
+				return err
+			}
+			silences = append(silences, silence)
+			return nil
+		})
+	})
+	sort.Slice(silences, func(i, j int) bool { return silences[i].StartAt.Before(silences[j].StartAt) })
+	return silences, err
+}
+
+func (db *boltStore) AddAlertTransition(t AlertTransition) error {
+	return db.putHistory(transitionsBucket, t.TestName, t.At, t.RunID, t)
+}
+
+func (db *boltStore) AddDeliveryAttempt(a DeliveryAttempt) error {
+	return db.putHistory(attemptsBucket, a.TestName, a.At, fmt.Sprintf("%s/%d", a.DeliveryID, a.Attempt), a)
+}
+
+func (db *boltStore) putHistory(bucket []byte, name string, at time.Time, id string, v interface{}) error {
+	return db.db.Update(func(tx *bolt.Tx) error {
+		buf, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		return tx.Bucket(bucket).Put(append(rollupPrefix(name), runKey(at, id)...), buf)
+	})
+}
+
+func (db *boltStore) ListAlertTransitions(name string, from, to time.Time) ([]AlertTransition, error) {
+	transitions := make([]AlertTransition, 0)
+	err := db.eachHistory(transitionsBucket, name, from, to, func(v []byte) error {
+		t := AlertTransition{}
+		if err := json.Unmarshal(v, &t); err != nil {
+			return err
+		}
+		transitions = append(transitions, t)
+		return nil
+	})
+	return transitions, err
+}
+
+func (db *boltStore) ListDeliveryAttempts(name string, from, to time.Time) ([]DeliveryAttempt, error) {
+	attempts := make([]DeliveryAttempt, 0)
+	err := db.eachHistory(attemptsBucket, name, from, to, func(v []byte) error {
+		a := DeliveryAttempt{}
+		if err := json.Unmarshal(v, &a); err != nil {
+			return err
+		}
+		attempts = append(attempts, a)
+		return nil
+	})
+	return attempts, err
+}
+
+func (db *boltStore) eachHistory(bucket []byte, name string, from, to time.Time, fn func(v []byte) error) error {
+	return db.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucket)
+		if b == nil {
+			// Read-only opening of a database that predates the history.
+			return nil
+		}
+		prefix := rollupPrefix(name)
+		c := b.Cursor()
+		for k, v := c.Seek(append(prefix, runKey(from, "")...)); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
+			if !runKeyTime(k[len(prefix):]).Before(to) {
+				break
+			}
+			if err := fn(v); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// pruneHistory deletes the history recorded before the given time.
+func pruneHistory(tx *bolt.Tx, bucket []byte, before time.Time) error {
+	b := tx.Bucket(bucket)
+	var stale [][]byte
+	err := b.ForEach(func(k, v []byte) error {
+		if i := bytes.IndexByte(k, 0); i >= 0 && runKeyTime(k[i+1:]).Before(before) {
+			stale = append(stale, k)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, k := range stale {
+		if err := b.Delete(k); err != nil {
+			return err
 		}
-	}()
-	sort.Sort(sort.Reverse(byStartBefore(ongoing)))
-	return ongoing, nil
+	}
+	return nil
+}
+
+// Prune deletes the tests that started before runsBefore, along with the
+// alert history, and the rollups of the periods that started before
+// rollupsBefore.
+func (db *boltStore) Prune(runsBefore, rollupsBefore time.Time) error {
+	return db.db.Update(func(tx *bolt.Tx) error {
+		var stale []TestInstance
+		c := tx.Bucket(runsByStartBucket).Cursor()
+		for k, _ := c.First(); k != nil && runKeyTime(k).Before(runsBefore); k, _ = c.Next() {
+			test := TestInstance{TestID: string(k[8:]), StartAt: runKeyTime(k)}
+			if v := tx.Bucket(testsBucket).Get(k[8:]); v != nil {
+				if err := json.Unmarshal(v, &test); err != nil {
+					return err
+				}
+			}
+			stale = append(stale, test)
+		}
+		for i := range stale {
+			if err := deleteTest(tx, &stale[i]); err != nil {
+				return err
+			}
+		}
+		if err := pruneHistory(tx, transitionsBucket, runsBefore); err != nil {
+			return err
+		}
+		if err := pruneHistory(tx, attemptsBucket, runsBefore); err != nil {
+			return err
+		}
+
+		for _, g := range Granularities {
+			b := tx.Bucket(rollupsBucket).Bucket([]byte(g))
+			var stale [][]byte
+			err := b.ForEach(func(k, v []byte) error {
+				if rollupKeyTime(k).Before(rollupsBefore) {
+					stale = append(stale, k)
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			for _, k := range stale {
+				if err := b.Delete(k); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// Backup writes the database as seen by a read transaction, so writes can
+// carry on while it's being copied.
+func (db *boltStore) Backup(w io.Writer) (int64, error) {
+	var n int64
+	err := db.db.View(func(tx *bolt.Tx) error {
+		var err error
+		n, err = tx.WriteTo(w)
+		return err
+	})
+	return n, err
+}
+
+func (db *boltStore) Ping() error {
+	// Committing writes the meta page, even when nothing changed.
+	return db.db.Update(func(tx *bolt.Tx) error { return nil })
 }
 
 func (db *boltStore) Close() error {
@@ -140,27 +556,102 @@ func (db *boltStore) Close() error {
 
 func insertTest(db *bolt.DB, test *TestInstance) error {
 	return db.Update(func(tx *bolt.Tx) error {
-		b := tx.Bucket(testsBucket)
+		if err := putTest(tx, test); err != nil {
+			return err
+		}
+		return addToRollups(tx, test)
+	})
+}
+
+// putTest saves the test, and indexes it.
+func putTest(tx *bolt.Tx, test *TestInstance) error {
+	// Make this something that is saveable by the database.
+	dbTest := &BoltTestInstance{
+		TestID:    test.TestID,
+		TestName:  test.TestName,
+		StartAt:   test.StartAt.UTC().Format(time.RFC3339Nano),
+		EndAt:     test.EndAt.UTC().Format(time.RFC3339Nano),
+		Pass:      test.Pass,
+		FailCause: test.FailCause,
+		FailKind:  test.FailKind,
+		Logs:      test.Logs,
+		Steps:     test.Steps,
+		HTTPTrips: test.HTTPTrips,
+	}
+
+	// Marshal and save the encoded test.
+	if buf, err := json.Marshal(dbTest); err != nil {
+		return err
+	} else if err := tx.Bucket(testsBucket).Put([]byte(dbTest.TestID), buf); err != nil {
+		return err
+	}
+
+	return indexTest(tx, test)
+}
+
+func indexTest(tx *bolt.Tx, test *TestInstance) error {
+	key := runKey(test.StartAt, test.TestID)
+	if err := tx.Bucket(runsByStartBucket).Put(key, []byte{}); err != nil {
+		return err
+	}
+	return tx.Bucket(runsByNameBucket).Put(append(rollupPrefix(test.TestName), key...), []byte{})
+}
 
-		// Make this something that is saveable by the database.
-		dbTest := &BoltTestInstance{
-			TestID:    test.TestID,
-			TestName:  test.TestName,
-			StartAt:   test.StartAt.UTC().Format(time.RFC3339),
-			EndAt:     test.StartAt.UTC().Format(time.RFC3339),
-			Pass:      test.Pass,
-			FailCause: test.FailCause,
+// deleteTest deletes the test, and its entries in the indexes.
+func deleteTest(tx *bolt.Tx, test *TestInstance) error {
+	key := runKey(test.StartAt, test.TestID)
+	if err := tx.Bucket(testsBucket).Delete([]byte(test.TestID)); err != nil {
+		return err
+	}
+	if err := tx.Bucket(runsByStartBucket).Delete(key); err != nil {
+		return err
+	}
+	return tx.Bucket(runsByNameBucket).Delete(append(rollupPrefix(test.TestName), key...))
+}
+
+// addToRollups accounts for the test in each of the rollups it falls in.
+func addToRollups(tx *bolt.Tx, test *TestInstance) error {
+	for _, g := range Granularities {
+		b := tx.Bucket(rollupsBucket).Bucket([]byte(g))
+		key := rollupKey(test.TestName, g.Truncate(test.StartAt))
+
+		rollup := newRollup(test, g)
+		if v := b.Get(key); v != nil {
+			if err := json.Unmarshal(v, rollup); err != nil {
+				return err
+			}
 		}
+		rollup.add(test)
 
-		// Marshal and save the encoded test.
-		if buf, err := json.Marshal(dbTest); err != nil {
+		buf, err := json.Marshal(rollup)
+		if err != nil {
 			return err
-		} else if err := b.Put([]byte(dbTest.TestID), buf); err != nil {
+		}
+		if err := b.Put(key, buf); err != nil {
 			return err
 		}
+	}
+	return nil
+}
 
-		return nil
-	})
+// Rollups are keyed by test name, then by the time at which their period
+// starts, so that the rollups of a test are sorted chronologically.
+func rollupPrefix(name string) []byte {
+	return append([]byte(name), 0)
+}
+
+func rollupKey(name string, start time.Time) []byte {
+	key := rollupPrefix(name)
+	ts := make([]byte, 8)
+	binary.BigEndian.PutUint64(ts, uint64(start.Unix()))
+	return append(key, ts...)
+}
+
+func rollupKeyTime(key []byte) time.Time {
+	if len(key) < 8 {
+		return time.Time{}
+	}
+	return time.Unix(int64(binary.BigEndian.Uint64(key[len(key)-8:])), 0).UTC()
 }
 
 func setupBucket(db *bolt.DB) error {
@@ -169,8 +660,65 @@ func setupBucket(db *bolt.DB) error {
 		if err != nil {
 			return err
 		}
-		return nil
+		if _, err := tx.CreateBucketIfNotExists(pausesBucket); err != nil {
+			return err
+		}
+		if _, err := tx.CreateBucketIfNotExists(alertsBucket); err != nil {
+			return err
+		}
+		if _, err := tx.CreateBucketIfNotExists(silencesBucket); err != nil {
+			return err
+		}
+		if _, err := tx.CreateBucketIfNotExists(transitionsBucket); err != nil {
+			return err
+		}
+		if _, err := tx.CreateBucketIfNotExists(attemptsBucket); err != nil {
+			return err
+		}
+		if tx.Bucket(runsByStartBucket) == nil {
+			if err := createRunIndexes(tx); err != nil {
+				return err
+			}
+		}
+		if tx.Bucket(rollupsBucket) != nil {
+			return nil
+		}
+		rollups, err := tx.CreateBucket(rollupsBucket)
+		if err != nil {
+			return err
+		}
+		for _, g := range Granularities {
+			if _, err := rollups.CreateBucket([]byte(g)); err != nil {
+				return err
+			}
+		}
+		// Backfill the rollups of the tests recorded before there were any.
+		return tx.Bucket(testsBucket).ForEach(func(k, v []byte) error {
+			test := TestInstance{}
+			if err := json.Unmarshal(v, &test); err != nil {
+				return err
+			}
+			return addToRollups(tx, &test)
+		})
 	})
 
 	return err
 }
+
+// createRunIndexes creates the indexes of the runs, and indexes the tests
+// recorded before there were any.
+func createRunIndexes(tx *bolt.Tx) error {
+	if _, err := tx.CreateBucket(runsByStartBucket); err != nil {
+		return err
+	}
+	if _, err := tx.CreateBucket(runsByNameBucket); err != nil {
+		return err
+	}
+	return tx.Bucket(testsBucket).ForEach(func(k, v []byte) error {
+		test := TestInstance{}
+		if err := json.Unmarshal(v, &test); err != nil {
+			return err
+		}
+		return indexTest(tx, &test)
+	})
+}
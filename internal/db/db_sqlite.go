@@ -0,0 +1,947 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	// Registers the "sqlite3" driver, backed by a build of SQLite that runs
+	// in a WebAssembly runtime written in pure Go.
+	_ "github.com/ncruces/go-sqlite3/driver"
+	_ "github.com/ncruces/go-sqlite3/embed"
+)
+
+var _ CanaryStore = (*sqliteStore)(nil)
+
+type sqliteStore struct {
+	db      *sql.DB
+	ongoing *ongoingTests
+}
+
+// sqliteTimeFormat is fixed width so that timestamps sort as text, and is
+// understood by the SQLite date and time functions.
+const sqliteTimeFormat = "2006-01-02T15:04:05.000000000Z"
+
+// sqliteSchema lays out the canary history so that it can be queried with
+// plain SQL: a row per run, and the details of each run in their own tables.
+const sqliteSchema = `
+CREATE TABLE IF NOT EXISTS runs (
+	id         TEXT PRIMARY KEY,
+	name       TEXT NOT NULL,
+	start_at   TEXT NOT NULL,
+	end_at     TEXT NOT NULL,
+	pass       INTEGER NOT NULL,
+	fail_cause TEXT NOT NULL DEFAULT '',
+	fail_kind TEXT NOT NULL DEFAULT ''
+);
+CREATE INDEX IF NOT EXISTS runs_by_name ON runs (name, start_at);
+CREATE INDEX IF NOT EXISTS runs_by_start ON runs (start_at);
+
+CREATE TABLE IF NOT EXISTS logs (
+	run_id  TEXT NOT NULL REFERENCES runs (id) ON DELETE CASCADE,
+	seq     INTEGER NOT NULL,
+	time    TEXT NOT NULL,
+	level   TEXT NOT NULL,
+	message TEXT NOT NULL,
+	fields  TEXT NOT NULL DEFAULT '{}',
+	PRIMARY KEY (run_id, seq)
+);
+
+CREATE TABLE IF NOT EXISTS steps (
+	run_id     TEXT NOT NULL REFERENCES runs (id) ON DELETE CASCADE,
+	seq        INTEGER NOT NULL,
+	name       TEXT NOT NULL,
+	start_at   TEXT NOT NULL,
+	end_at     TEXT NOT NULL,
+	pass       INTEGER NOT NULL,
+	fail_cause TEXT NOT NULL DEFAULT '',
+	PRIMARY KEY (run_id, seq)
+);
+
+CREATE TABLE IF NOT EXISTS http_trips (
+	run_id      TEXT NOT NULL REFERENCES runs (id) ON DELETE CASCADE,
+	seq         INTEGER NOT NULL,
+	method      TEXT NOT NULL,
+	url         TEXT NOT NULL,
+	status_code INTEGER NOT NULL DEFAULT 0,
+	start_at    TEXT NOT NULL,
+	duration_ns INTEGER NOT NULL,
+	error       TEXT NOT NULL DEFAULT '',
+	PRIMARY KEY (run_id, seq)
+);
+
+CREATE TABLE IF NOT EXISTS rollups (
+	name         TEXT NOT NULL,
+	granularity  TEXT NOT NULL,
+	start_at     TEXT NOT NULL,
+	runs         INTEGER NOT NULL,
+	passes       INTEGER NOT NULL,
+	failures     INTEGER NOT NULL,
+	min_duration INTEGER NOT NULL,
+	max_duration INTEGER NOT NULL,
+	sum_duration INTEGER NOT NULL,
+	buckets      TEXT NOT NULL,
+	PRIMARY KEY (name, granularity, start_at)
+);
+
+CREATE TABLE IF NOT EXISTS pauses (
+	name      TEXT PRIMARY KEY,
+	until     TEXT NOT NULL,
+	reason    TEXT NOT NULL DEFAULT '',
+	paused_by TEXT NOT NULL DEFAULT '',
+	paused_at TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS alerts (
+	name                 TEXT PRIMARY KEY,
+	firing               INTEGER NOT NULL,
+	since                TEXT NOT NULL,
+	consecutive_failures INTEGER NOT NULL,
+	consecutive_passes   INTEGER NOT NULL,
+	failures             TEXT NOT NULL DEFAULT '[]',
+	changes              TEXT NOT NULL DEFAULT '[]',
+	flapping             INTEGER NOT NULL,
+	notified             INTEGER NOT NULL,
+	last_run_id          TEXT NOT NULL DEFAULT ''
+);
+
+CREATE TABLE IF NOT EXISTS alert_transitions (
+	name     TEXT NOT NULL,
+	at       TEXT NOT NULL,
+	run_id   TEXT NOT NULL DEFAULT '',
+	firing   INTEGER NOT NULL,
+	flapping INTEGER NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS alert_transitions_by_name ON alert_transitions (name, at);
+
+CREATE TABLE IF NOT EXISTS delivery_attempts (
+	delivery_id TEXT NOT NULL,
+	attempt     INTEGER NOT NULL,
+	notifier    TEXT NOT NULL,
+	name        TEXT NOT NULL,
+	run_id      TEXT NOT NULL DEFAULT '',
+	at          TEXT NOT NULL,
+	latency_ns  INTEGER NOT NULL,
+	status_code INTEGER NOT NULL DEFAULT 0,
+	error       TEXT NOT NULL DEFAULT '',
+	final       INTEGER NOT NULL,
+	silenced_by TEXT NOT NULL DEFAULT '',
+	PRIMARY KEY (delivery_id, attempt)
+);
+
+CREATE INDEX IF NOT EXISTS delivery_attempts_by_name ON delivery_attempts (name, at);
+
+CREATE TABLE IF NOT EXISTS silences (
+	id         TEXT PRIMARY KEY,
+	tests      TEXT NOT NULL DEFAULT '',
+	labels     TEXT NOT NULL DEFAULT '{}',
+	start_at   TEXT NOT NULL,
+	end_at     TEXT NOT NULL,
+	comment    TEXT NOT NULL DEFAULT '',
+	created_by TEXT NOT NULL DEFAULT '',
+	created_at TEXT NOT NULL
+);
+`
+
+// NewSQLiteStore creates a new instance of the SQLiteStore
+func NewSQLiteStore(path string) (CanaryStore, error) {
+	dsn := fmt.Sprintf("file:%s?_pragma=busy_timeout(10000)&_pragma=foreign_keys(1)", path)
+	db, err := sql.Open("sqlite3", dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	var hadRollups int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'rollups'`).Scan(&hadRollups); err != nil {
+		db.Close()
+		return nil, err
+	}
+	if _, err := db.Exec(sqliteSchema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("can't setup schema: %v", err)
+	}
+	if err := addSQLiteFailKind(db); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("can't add the fail_kind of runs: %v", err)
+	}
+	if hadRollups == 0 {
+		if err := backfillSQLiteRollups(db); err != nil {
+			db.Close()
+			return nil, fmt.Errorf("can't backfill rollups: %v", err)
+		}
+	}
+
+	return &sqliteStore{
+		db:      db,
+		ongoing: newOngoingTests(),
+	}, nil
+}
+
+// StartTest keeps a running test in memory until it is ended.
+func (db *sqliteStore) StartTest(id string, testName string, startTime time.Time) (*TestInstance, error) {
+	test := &TestInstance{
+		TestID:   id,
+		TestName: testName,
+		StartAt:  startTime.UTC(),
+	}
+	db.ongoing.start(*test)
+	return test, nil
+}
+
+// EndTest marks a test as ended and saves it, along with its details.
+func (db *sqliteStore) EndTest(test *TestInstance, failure error, endAt time.Time) error {
+	t, ok := db.ongoing.end(test.TestID)
+	if !ok {
+		return fmt.Errorf("test with ID does not exist: %q", test.TestID)
+	}
+
+	t.Pass = failure == nil
+	if failure != nil {
+		t.FailCause = failure.Error()
+		t.FailKind = test.FailKind
+	}
+	t.EndAt = endAt
+	t.Logs = test.Logs
+	t.Steps = test.Steps
+	t.HTTPTrips = test.HTTPTrips
+
+	tx, err := db.db.Begin()
+	if err != nil {
+		return err
+	}
+	if err := insertSQLiteTest(tx, &t); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// ImportTest saves a test that has ended, unless its ID is already known.
+func (db *sqliteStore) ImportTest(test *TestInstance) (bool, error) {
+	tx, err := db.db.Begin()
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	var n int
+	if err := tx.QueryRow(`SELECT COUNT(*) FROM runs WHERE id = ?`, test.TestID).Scan(&n); err != nil {
+		return false, err
+	}
+	if n > 0 {
+		return false, nil
+	}
+	if err := insertSQLiteTest(tx, test); err != nil {
+		return false, err
+	}
+	if err := tx.Commit(); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (db *sqliteStore) ListTests() ([]TestInstance, error) {
+	rows, err := db.db.Query(`SELECT id, name, start_at, end_at, pass, fail_cause, fail_kind FROM runs ORDER BY id`)
+	if err != nil {
+		return nil, err
+	}
+	tests, err := scanSQLiteTests(rows)
+	if err != nil {
+		return nil, err
+	}
+	return tests, loadSQLiteDetails(db.db, tests, true)
+}
+
+// ListRuns pages through the runs by start time and ID, which the indexes on
+// the runs keep in order.
+func (db *sqliteStore) ListRuns(filter RunFilter, first int, after string) (*RunPage, error) {
+	var (
+		where []string
+		args  []interface{}
+	)
+	if filter.Name != "" {
+		where = append(where, "name = ?")
+		args = append(args, filter.Name)
+	}
+	if filter.Pass != nil {
+		where = append(where, "pass = ?")
+		args = append(args, *filter.Pass)
+	}
+	if filter.FailKind != "" {
+		where = append(where, "fail_kind = ?")
+		args = append(args, string(filter.FailKind))
+	}
+	if !filter.From.IsZero() {
+		where = append(where, "start_at >= ?")
+		args = append(args, formatSQLiteTime(filter.From))
+	}
+	if !filter.To.IsZero() {
+		where = append(where, "start_at < ?")
+		args = append(args, formatSQLiteTime(filter.To))
+	}
+
+	page := &RunPage{}
+	if err := db.db.QueryRow(`SELECT COUNT(*) FROM runs`+sqliteWhere(where), args...).Scan(&page.TotalCount); err != nil {
+		return nil, err
+	}
+
+	if after != "" {
+		startAt, id, err := parseRunCursor(after)
+		if err != nil {
+			return nil, err
+		}
+		where = append(where, "(start_at < ? OR (start_at = ? AND id < ?))")
+		args = append(args, formatSQLiteTime(startAt), formatSQLiteTime(startAt), id)
+	}
+	limit := -1
+	if first > 0 {
+		// Fetch one more run than asked for, to know if there are more.
+		limit = first + 1
+	}
+	args = append(args, limit)
+
+	rows, err := db.db.Query(
+		`SELECT id, name, start_at, end_at, pass, fail_cause, fail_kind FROM runs`+sqliteWhere(where)+`
+		ORDER BY start_at DESC, id DESC LIMIT ?`,
+		args...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	page.Runs, err = scanSQLiteTests(rows)
+	if err != nil {
+		return nil, err
+	}
+	if first > 0 && len(page.Runs) > first {
+		page.Runs = page.Runs[:first]
+		page.HasNextPage = true
+	}
+	return page, loadSQLiteDetails(db.db, page.Runs, first <= 0)
+}
+
+func sqliteWhere(conds []string) string {
+	if len(conds) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(conds, " AND ")
+}
+
+// FindTestByID finds a specific test given it's ID.
+func (db *sqliteStore) FindTestByID(id string) (*TestInstance, error) {
+	rows, err := db.db.Query(`SELECT id, name, start_at, end_at, pass, fail_cause, fail_kind FROM runs WHERE id = ?`, id)
+	if err != nil {
+		return nil, err
+	}
+	tests, err := scanSQLiteTests(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(tests) == 0 {
+		return nil, fmt.Errorf("test with ID does not exist: %q", id)
+	}
+	if err := loadSQLiteDetails(db.db, tests, false); err != nil {
+		return nil, err
+	}
+	return &tests[0], nil
+}
+
+// ListOngoingTests returns a list of ongoing tests. A test is ongong if it has
+// never be ended with EndTest.
+func (db *sqliteStore) ListOngoingTests() ([]TestInstance, error) {
+	return db.ongoing.list(), nil
+}
+
+// ListRollups returns the rollups of a test at a granularity, for the periods
+// starting between from and to.
+func (db *sqliteStore) ListRollups(name string, g Granularity, from, to time.Time) ([]Rollup, error) {
+	rows, err := db.db.Query(
+		`SELECT name, granularity, start_at, runs, passes, failures, min_duration, max_duration, sum_duration, buckets
+		FROM rollups WHERE name = ? AND granularity = ? AND start_at >= ? AND start_at < ?
+		ORDER BY start_at`,
+		name, string(g), formatSQLiteTime(g.Truncate(from)), formatSQLiteTime(to),
+	)
+	if err != nil {
+		return nil, err
+	}
+	rollups := make([]Rollup, 0)
+	err = eachSQLiteRow(rows, func() error {
+		rollup, err := scanSQLiteRollup(rows)
+		if err != nil {
+			return err
+		}
+		rollups = append(rollups, *rollup)
+		return nil
+	})
+	return rollups, err
+}
+
+// PauseTest saves the pause, replacing any previous one of the test.
+func (db *sqliteStore) PauseTest(pause Pause) error {
+	_, err := db.db.Exec(
+		`INSERT OR REPLACE INTO pauses (name, until, reason, paused_by, paused_at) VALUES (?, ?, ?, ?, ?)`,
+		pause.TestName, formatSQLiteTime(pause.Until), pause.Reason, pause.PausedBy, formatSQLiteTime(pause.PausedAt),
+	)
+	return err
+}
+
+// ResumeTest forgets about the pause of the test, if any.
+func (db *sqliteStore) ResumeTest(name string) error {
+	_, err := db.db.Exec(`DELETE FROM pauses WHERE name = ?`, name)
+	return err
+}
+
+func (db *sqliteStore) ListPauses() ([]Pause, error) {
+	rows, err := db.db.Query(`SELECT name, until, reason, paused_by, paused_at FROM pauses ORDER BY name`)
+	if err != nil {
+		return nil, err
+	}
+	pauses := make([]Pause, 0)
+	err = eachSQLiteRow(rows, func() error {
+		var (
+			pause           Pause
+			until, pausedAt string
+		)
+		err := rows.Scan(&pause.TestName, &until, &pause.Reason, &pause.PausedBy, &pausedAt)
+		if err != nil {
+			return err
+		}
+		if pause.Until, err = parseSQLiteTime(until); err != nil {
+			return err
+		}
+		if pause.PausedAt, err = parseSQLiteTime(pausedAt); err != nil {
+			return err
+		}
+		pauses = append(pauses, pause)
+		return nil
+	})
+	return pauses, err
+}
+
+// PutAlertState saves the alert state, replacing any previous one of the test.
+func (db *sqliteStore) PutAlertState(state AlertState) error {
+	failures, err := json.Marshal(state.Failures)
+	if err != nil {
+		return err
+	}
+	changes, err := json.Marshal(state.Changes)
+	if err != nil {
+		return err
+	}
+	_, err = db.db.Exec(
+		`INSERT OR REPLACE INTO alerts (name, firing, since, consecutive_failures, consecutive_passes, failures, changes, flapping, notified, last_run_id)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		state.TestName, state.Firing, formatSQLiteTime(state.Since), state.ConsecutiveFailures, state.ConsecutivePasses,
+		string(failures), string(changes), state.Flapping, state.Notified, state.LastRunID,
+	)
+	return err
+}
+
+func (db *sqliteStore) ListAlertStates() ([]AlertState, error) {
+	rows, err := db.db.Query(`SELECT name, firing, since, consecutive_failures, consecutive_passes, failures, changes, flapping, notified, last_run_id FROM alerts ORDER BY name`)
+	if err != nil {
+		return nil, err
+	}
+	states := make([]AlertState, 0)
+	err = eachSQLiteRow(rows, func() error {
+		var (
+			state             AlertState
+			since             string
+			failures, changes string
+		)
+		err := rows.Scan(&state.TestName, &state.Firing, &since, &state.ConsecutiveFailures, &state.ConsecutivePasses,
+			&failures, &changes, &state.Flapping, &state.Notified, &state.LastRunID)
+		if err != nil {
+			return err
+		}
+		if state.Since, err = parseSQLiteTime(since); err != nil {
+			return err
+		}
+		if err := json.Unmarshal([]byte(failures), &state.Failures); err != nil {
+			return err
+		}
+		if err := json.Unmarshal([]byte(changes), &state.Changes); err != nil {
+			return err
+		}
+		states = append(states, state)
+		return nil
+	})
+	return states, err
+}
+
+// PutSilence saves the silence, replacing any previous one with the same ID.
+func (db *sqliteStore) PutSilence(silence Silence) error {
+	labels, err := json.Marshal(silence.Labels)
+	if err != nil {
+		return err
+	}
+	_, err = db.db.Exec(
+		`INSERT OR REPLACE INTO silences (id, tests, labels, start_at, end_at, comment, created_by, created_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		silence.ID, silence.Tests, string(labels), formatSQLiteTime(silence.StartAt), formatSQLiteTime(silence.EndAt),
+		silence.Comment, silence.CreatedBy, formatSQLiteTime(silence.CreatedAt),
+	)
+	return err
+}
+
+func (db *sqliteStore) ListSilences() ([]Silence, error) {
+	rows, err := db.db.Query(`SELECT id, tests, labels, start_at, end_at, comment, created_by, created_at FROM silences ORDER BY start_at`)
+	if err != nil {
+		return nil, err
+	}
+	silences := make([]Silence, 0)
+	err = eachSQLiteRow(rows, func() error {
+		var (
+			silence                   Silence
+			labels                    string
+			startAt, endAt, createdAt string
+		)
+		err := rows.Scan(&silence.ID, &silence.Tests, &labels, &startAt, &endAt, &silence.Comment, &silence.CreatedBy, &createdAt)
+		if err != nil {
+			return err
+		}
+		if err := json.Unmarshal([]byte(labels), &silence.Labels); err != nil {
+			return err
+		}
+		if silence.StartAt, err = parseSQLiteTime(startAt); err != nil {
+			return err
+		}
+		if silence.EndAt, err = parseSQLiteTime(endAt); err != nil {
+			return err
+		}
+		if silence.CreatedAt, err = parseSQLiteTime(createdAt); err != nil {
+			return err
+		}
+		silences = append(silences, silence)
+		return nil
+	})
+	return silences, err
+}
+
+func (db *sqliteStore) AddAlertTransition(t AlertTransition) error {
+	_, err := db.db.Exec(
+		`INSERT INTO alert_transitions (name, at, run_id, firing, flapping) VALUES (?, ?, ?, ?, ?)`,
+		t.TestName, formatSQLiteTime(t.At), t.RunID, t.Firing, t.Flapping,
+	)
+	return err
+}
+
+func (db *sqliteStore) AddDeliveryAttempt(a DeliveryAttempt) error {
+	_, err := db.db.Exec(
+		`INSERT OR REPLACE INTO delivery_attempts (delivery_id, attempt, notifier, name, run_id, at, latency_ns, status_code, error, final, silenced_by)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		a.DeliveryID, a.Attempt, a.Notifier, a.TestName, a.RunID, formatSQLiteTime(a.At), int64(a.Latency),
+		a.StatusCode, a.Error, a.Final, a.SilencedBy,
+	)
+	return err
+}
+
+func (db *sqliteStore) ListAlertTransitions(name string, from, to time.Time) ([]AlertTransition, error) {
+	rows, err := db.db.Query(
+		`SELECT name, at, run_id, firing, flapping FROM alert_transitions WHERE name = ? AND at >= ? AND at < ? ORDER BY at`,
+		name, formatSQLiteTime(from), formatSQLiteTime(to),
+	)
+	if err != nil {
+		return nil, err
+	}
+	transitions := make([]AlertTransition, 0)
+	err = eachSQLiteRow(rows, func() error {
+		var (
+			t  AlertTransition
+			at string
+		)
+		err := rows.Scan(&t.TestName, &at, &t.RunID, &t.Firing, &t.Flapping)
+		if err != nil {
+			return err
+		}
+		if t.At, err = parseSQLiteTime(at); err != nil {
+			return err
+		}
+		transitions = append(transitions, t)
+		return nil
+	})
+	return transitions, err
+}
+
+func (db *sqliteStore) ListDeliveryAttempts(name string, from, to time.Time) ([]DeliveryAttempt, error) {
+	rows, err := db.db.Query(
+		`SELECT delivery_id, attempt, notifier, name, run_id, at, latency_ns, status_code, error, final, silenced_by
+		FROM delivery_attempts WHERE name = ? AND at >= ? AND at < ? ORDER BY at`,
+		name, formatSQLiteTime(from), formatSQLiteTime(to),
+	)
+	if err != nil {
+		return nil, err
+	}
+	attempts := make([]DeliveryAttempt, 0)
+	err = eachSQLiteRow(rows, func() error {
+		var (
+			a       DeliveryAttempt
+			at      string
+			latency int64
+		)
+		err := rows.Scan(&a.DeliveryID, &a.Attempt, &a.Notifier, &a.TestName, &a.RunID, &at, &latency,
+			&a.StatusCode, &a.Error, &a.Final, &a.SilencedBy)
+		if err != nil {
+			return err
+		}
+		if a.At, err = parseSQLiteTime(at); err != nil {
+			return err
+		}
+		a.Latency = time.Duration(latency)
+		attempts = append(attempts, a)
+		return nil
+	})
+	return attempts, err
+}
+
+// Prune deletes the tests that started before runsBefore, along with the
+// alert history, and the rollups of the periods that started before
+// rollupsBefore.
+func (db *sqliteStore) Prune(runsBefore, rollupsBefore time.Time) error {
+	tx, err := db.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if _, err := tx.Exec(`DELETE FROM runs WHERE start_at < ?`, formatSQLiteTime(runsBefore)); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`DELETE FROM rollups WHERE start_at < ?`, formatSQLiteTime(rollupsBefore)); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`DELETE FROM alert_transitions WHERE at < ?`, formatSQLiteTime(runsBefore)); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`DELETE FROM delivery_attempts WHERE at < ?`, formatSQLiteTime(runsBefore)); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// Backup has SQLite write a copy of the database to a temporary file, which
+// is then written to w.
+func (db *sqliteStore) Backup(w io.Writer) (int64, error) {
+	dir, err := ioutil.TempDir("", "canary-backup")
+	if err != nil {
+		return 0, err
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "canary.db")
+	if _, err := db.db.Exec(`VACUUM INTO ?`, path); err != nil {
+		return 0, fmt.Errorf("can't snapshot database: %v", err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	return io.Copy(w, f)
+}
+
+func (db *sqliteStore) Ping() error {
+	ctx := context.Background()
+	conn, err := db.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	// Taking the write lock fails if the database can't be written to.
+	if _, err := conn.ExecContext(ctx, `BEGIN IMMEDIATE`); err != nil {
+		return err
+	}
+	_, err = conn.ExecContext(ctx, `ROLLBACK`)
+	return err
+}
+
+func (db *sqliteStore) Close() error {
+	return db.db.Close()
+}
+
+func insertSQLiteTest(tx *sql.Tx, test *TestInstance) error {
+	_, err := tx.Exec(
+		`INSERT INTO runs (id, name, start_at, end_at, pass, fail_cause, fail_kind) VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		test.TestID, test.TestName, formatSQLiteTime(test.StartAt), formatSQLiteTime(test.EndAt), test.Pass, test.FailCause, string(test.FailKind),
+	)
+	if err != nil {
+		return err
+	}
+
+	for i, l := range test.Logs {
+		fields, err := json.Marshal(l.Fields)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(
+			`INSERT INTO logs (run_id, seq, time, level, message, fields) VALUES (?, ?, ?, ?, ?, ?)`,
+			test.TestID, i, formatSQLiteTime(l.Time), l.Level, l.Message, string(fields),
+		); err != nil {
+			return err
+		}
+	}
+
+	for i, s := range test.Steps {
+		if _, err := tx.Exec(
+			`INSERT INTO steps (run_id, seq, name, start_at, end_at, pass, fail_cause) VALUES (?, ?, ?, ?, ?, ?, ?)`,
+			test.TestID, i, s.Name, formatSQLiteTime(s.StartAt), formatSQLiteTime(s.EndAt), s.Pass, s.FailCause,
+		); err != nil {
+			return err
+		}
+	}
+
+	for i, h := range test.HTTPTrips {
+		if _, err := tx.Exec(
+			`INSERT INTO http_trips (run_id, seq, method, url, status_code, start_at, duration_ns, error) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+			test.TestID, i, h.Method, h.URL, h.StatusCode, formatSQLiteTime(h.StartAt), int64(h.Duration), h.Error,
+		); err != nil {
+			return err
+		}
+	}
+	return addToSQLiteRollups(tx, test)
+}
+
+// addToSQLiteRollups accounts for the test in each of the rollups it falls in.
+func addToSQLiteRollups(tx *sql.Tx, test *TestInstance) error {
+	for _, g := range Granularities {
+		start := formatSQLiteTime(g.Truncate(test.StartAt))
+		rows, err := tx.Query(
+			`SELECT name, granularity, start_at, runs, passes, failures, min_duration, max_duration, sum_duration, buckets
+			FROM rollups WHERE name = ? AND granularity = ? AND start_at = ?`,
+			test.TestName, string(g), start,
+		)
+		if err != nil {
+			return err
+		}
+		rollup := newRollup(test, g)
+		err = eachSQLiteRow(rows, func() error {
+			var err error
+			rollup, err = scanSQLiteRollup(rows)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		rollup.add(test)
+
+		buckets, err := json.Marshal(rollup.Buckets)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(
+			`INSERT OR REPLACE INTO rollups (name, granularity, start_at, runs, passes, failures, min_duration, max_duration, sum_duration, buckets)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			rollup.TestName, string(g), start, rollup.Runs, rollup.Passes, rollup.Failures,
+			int64(rollup.MinDuration), int64(rollup.MaxDuration), int64(rollup.SumDuration), string(buckets),
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addSQLiteFailKind adds the fail_kind column to the runs of databases that
+// predate it. Their failed runs are left unclassified.
+func addSQLiteFailKind(db *sql.DB) error {
+	var n int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM pragma_table_info('runs') WHERE name = 'fail_kind'`).Scan(&n); err != nil {
+		return err
+	}
+	if n > 0 {
+		return nil
+	}
+	_, err := db.Exec(`ALTER TABLE runs ADD COLUMN fail_kind TEXT NOT NULL DEFAULT ''`)
+	return err
+}
+
+func backfillSQLiteRollups(db *sql.DB) error {
+	rows, err := db.Query(`SELECT id, name, start_at, end_at, pass, fail_cause, fail_kind FROM runs`)
+	if err != nil {
+		return err
+	}
+	tests, err := scanSQLiteTests(rows)
+	if err != nil {
+		return err
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	for i := range tests {
+		if err := addToSQLiteRollups(tx, &tests[i]); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func scanSQLiteRollup(rows *sql.Rows) (*Rollup, error) {
+	var (
+		rollup                 Rollup
+		g, start, buckets      string
+		minDur, maxDur, sumDur int64
+	)
+	err := rows.Scan(&rollup.TestName, &g, &start, &rollup.Runs, &rollup.Passes, &rollup.Failures, &minDur, &maxDur, &sumDur, &buckets)
+	if err != nil {
+		return nil, err
+	}
+	rollup.Granularity = Granularity(g)
+	if rollup.StartAt, err = parseSQLiteTime(start); err != nil {
+		return nil, err
+	}
+	rollup.MinDuration = time.Duration(minDur)
+	rollup.MaxDuration = time.Duration(maxDur)
+	rollup.SumDuration = time.Duration(sumDur)
+	return &rollup, json.Unmarshal([]byte(buckets), &rollup.Buckets)
+}
+
+func scanSQLiteTests(rows *sql.Rows) ([]TestInstance, error) {
+	defer rows.Close()
+	tests := make([]TestInstance, 0)
+	for rows.Next() {
+		var (
+			test                     TestInstance
+			startAt, endAt, failKind string
+		)
+		err := rows.Scan(&test.TestID, &test.TestName, &startAt, &endAt, &test.Pass, &test.FailCause, &failKind)
+		if err != nil {
+			return nil, err
+		}
+		test.FailKind = FailureKind(failKind)
+		if test.StartAt, err = parseSQLiteTime(startAt); err != nil {
+			return nil, err
+		}
+		if test.EndAt, err = parseSQLiteTime(endAt); err != nil {
+			return nil, err
+		}
+		tests = append(tests, test)
+	}
+	return tests, rows.Err()
+}
+
+// loadSQLiteDetails fills in the logs, steps and HTTP trips of the tests. If
+// all is false, only the details of these tests are looked up rather than
+// those of every test.
+func loadSQLiteDetails(db *sql.DB, tests []TestInstance, all bool) error {
+	if len(tests) == 0 {
+		return nil
+	}
+	byID := make(map[string]*TestInstance, len(tests))
+	for i := range tests {
+		byID[tests[i].TestID] = &tests[i]
+	}
+	where := ""
+	args := []interface{}{}
+	if !all {
+		where = "WHERE run_id IN (?" + strings.Repeat(", ?", len(tests)-1) + ")"
+		for _, test := range tests {
+			args = append(args, test.TestID)
+		}
+	}
+
+	rows, err := db.Query(`SELECT run_id, time, level, message, fields FROM logs `+where+` ORDER BY run_id, seq`, args...)
+	if err != nil {
+		return err
+	}
+	err = eachSQLiteRow(rows, func() error {
+		var (
+			runID, t, fields string
+			l                LogEntry
+		)
+		err := rows.Scan(&runID, &t, &l.Level, &l.Message, &fields)
+		if err != nil {
+			return err
+		}
+		if l.Time, err = parseSQLiteTime(t); err != nil {
+			return err
+		}
+		if err := json.Unmarshal([]byte(fields), &l.Fields); err != nil {
+			return err
+		}
+		if test, ok := byID[runID]; ok {
+			test.Logs = append(test.Logs, l)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	rows, err = db.Query(`SELECT run_id, name, start_at, end_at, pass, fail_cause FROM steps `+where+` ORDER BY run_id, seq`, args...)
+	if err != nil {
+		return err
+	}
+	err = eachSQLiteRow(rows, func() error {
+		var (
+			runID, startAt, endAt string
+			s                     Step
+		)
+		err := rows.Scan(&runID, &s.Name, &startAt, &endAt, &s.Pass, &s.FailCause)
+		if err != nil {
+			return err
+		}
+		if s.StartAt, err = parseSQLiteTime(startAt); err != nil {
+			return err
+		}
+		if s.EndAt, err = parseSQLiteTime(endAt); err != nil {
+			return err
+		}
+		if test, ok := byID[runID]; ok {
+			test.Steps = append(test.Steps, s)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	rows, err = db.Query(`SELECT run_id, method, url, status_code, start_at, duration_ns, error FROM http_trips `+where+` ORDER BY run_id, seq`, args...)
+	if err != nil {
+		return err
+	}
+	return eachSQLiteRow(rows, func() error {
+		var (
+			runID, startAt string
+			duration       int64
+			h              HTTPTrip
+		)
+		err := rows.Scan(&runID, &h.Method, &h.URL, &h.StatusCode, &startAt, &duration, &h.Error)
+		if err != nil {
+			return err
+		}
+		if h.StartAt, err = parseSQLiteTime(startAt); err != nil {
+			return err
+		}
+		h.Duration = time.Duration(duration)
+		if test, ok := byID[runID]; ok {
+			test.HTTPTrips = append(test.HTTPTrips, h)
+		}
+		return nil
+	})
+}
+
+func eachSQLiteRow(rows *sql.Rows, fn func() error) error {
+	defer rows.Close()
+	for rows.Next() {
+		if err := fn(); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+func formatSQLiteTime(t time.Time) string {
+	return t.UTC().Format(sqliteTimeFormat)
+}
+
+func parseSQLiteTime(s string) (time.Time, error) {
+	return time.Parse(sqliteTimeFormat, s)
+}
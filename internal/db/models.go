@@ -1,6 +1,10 @@
 package db
 
-import "time"
+import (
+	"fmt"
+	"path"
+	"time"
+)
 
 // TestInstance collects details about the instance of a unique
 // test execution.
@@ -11,21 +15,197 @@ type TestInstance struct {
 	EndAt     time.Time `json:"end_at,omitempty"`
 	Pass      bool      `json:"pass,omitempty"`
 	FailCause string    `json:"fail_cause,omitempty"`
-	// Logs         []*logspy.Event        `json:"logs,omitempty"`
-	// HTTPRequests []transport.TripRecord `json:"http_requests,omitempty"`
+	// FailKind classifies the failure of the run, if it failed.
+	FailKind  FailureKind `json:"fail_kind,omitempty"`
+	Logs      []LogEntry  `json:"logs,omitempty"`
+	Steps     []Step      `json:"steps,omitempty"`
+	HTTPTrips []HTTPTrip  `json:"http_trips,omitempty"`
 }
 
 // BoltTestInstance is what gets serialized and saved to the Bolt database. Only
 // difference is that we're going to be using strings for StartAt and EndAt
 type BoltTestInstance struct {
-	TestID    string `json:"id,omitempty"`
-	TestName  string `json:"name,omitempty"`
-	StartAt   string `json:"start_at,omitempty"`
-	EndAt     string `json:"end_at,omitempty"`
-	Pass      bool   `json:"pass,omitempty"`
-	FailCause string `json:"fail_cause,omitempty"`
-	// Logs         []*logspy.Event        `json:"logs,omitempty"`
-	// HTTPRequests []transport.TripRecord `json:"http_requests,omitempty"`
+	TestID    string      `json:"id,omitempty"`
+	TestName  string      `json:"name,omitempty"`
+	StartAt   string      `json:"start_at,omitempty"`
+	EndAt     string      `json:"end_at,omitempty"`
+	Pass      bool        `json:"pass,omitempty"`
+	FailCause string      `json:"fail_cause,omitempty"`
+	FailKind  FailureKind `json:"fail_kind,omitempty"`
+	Logs      []LogEntry  `json:"logs,omitempty"`
+	Steps     []Step      `json:"steps,omitempty"`
+	HTTPTrips []HTTPTrip  `json:"http_trips,omitempty"`
+}
+
+// A FailureKind tells what made a run fail, so that the failures of the
+// canary itself aren't mistaken for outages of its targets.
+type FailureKind string
+
+// The kinds of failures.
+const (
+	// FailTimeout is a run that didn't finish within its timeout.
+	FailTimeout FailureKind = "timeout"
+	// FailAssertion is a test that failed a check, through log.fail.
+	FailAssertion FailureKind = "assertion"
+	// FailScriptError is a script that threw an error of its own.
+	FailScriptError FailureKind = "script_error"
+	// FailNetwork is a request that couldn't reach its target.
+	FailNetwork FailureKind = "network"
+	// FailTLS is a request whose TLS handshake failed.
+	FailTLS FailureKind = "tls"
+	// FailDNS is a request whose host couldn't be resolved.
+	FailDNS FailureKind = "dns"
+	// FailInfrastructure is a run that the canary couldn't set up.
+	FailInfrastructure FailureKind = "infrastructure"
+)
+
+// FailureKinds lists the kinds of failures.
+var FailureKinds = []FailureKind{
+	FailTimeout, FailAssertion, FailScriptError, FailNetwork, FailTLS, FailDNS, FailInfrastructure,
+}
+
+// ParseFailureKind returns the kind of failures with the given name.
+func ParseFailureKind(name string) (FailureKind, error) {
+	for _, kind := range FailureKinds {
+		if string(kind) == name {
+			return kind, nil
+		}
+	}
+	return "", fmt.Errorf("unknown kind of failure %q, should be one of: timeout, assertion, script_error, network, tls, dns, infrastructure", name)
+}
+
+// A LogEntry is a line that was logged by a test while it was running.
+type LogEntry struct {
+	Time    time.Time         `json:"time"`
+	Level   string            `json:"level"`
+	Message string            `json:"message"`
+	Fields  map[string]string `json:"fields,omitempty"`
+}
+
+// A Step is a named section of a test, with its own outcome.
+type Step struct {
+	Name      string    `json:"name"`
+	StartAt   time.Time `json:"start_at"`
+	EndAt     time.Time `json:"end_at"`
+	Pass      bool      `json:"pass"`
+	FailCause string    `json:"fail_cause,omitempty"`
+}
+
+// An HTTPTrip records an HTTP request made by a test, and what came of it.
+type HTTPTrip struct {
+	Method     string        `json:"method"`
+	URL        string        `json:"url"`
+	StatusCode int           `json:"status_code,omitempty"`
+	StartAt    time.Time     `json:"start_at"`
+	Duration   time.Duration `json:"duration"`
+	Error      string        `json:"error,omitempty"`
+}
+
+// A Pause keeps a test from being run, typically while its target is under
+// maintenance.
+type Pause struct {
+	TestName string `json:"name"`
+	// Until is when the test can run again. If zero, it's paused until it's
+	// resumed.
+	Until    time.Time `json:"until,omitempty"`
+	Reason   string    `json:"reason"`
+	PausedBy string    `json:"paused_by"`
+	PausedAt time.Time `json:"paused_at"`
+}
+
+// Active tells whether the pause is still in effect at the given time.
+func (p *Pause) Active(now time.Time) bool {
+	return p.Until.IsZero() || now.Before(p.Until)
+}
+
+// AlertState is where a test stands with regard to alerting, as of its last
+// run.
+type AlertState struct {
+	TestName string `json:"name"`
+	Firing   bool   `json:"firing"`
+	// Since is when the alert last started or stopped firing.
+	Since               time.Time `json:"since,omitempty"`
+	ConsecutiveFailures int       `json:"consecutive_failures"`
+	ConsecutivePasses   int       `json:"consecutive_passes"`
+	// Failures are the ends of the failed runs within the window of the
+	// alert rule, if it counts them.
+	Failures []time.Time `json:"failures,omitempty"`
+	// Changes are the times at which the alert started or stopped firing,
+	// within the window of flap detection.
+	Changes  []time.Time `json:"changes,omitempty"`
+	Flapping bool        `json:"flapping"`
+	// Notified tells whether the notifiers were last told that the alert
+	// is firing, rather than resolved.
+	Notified  bool   `json:"notified"`
+	LastRunID string `json:"last_run_id"`
+}
+
+// An AlertTransition records that the alert of a test started or stopped
+// firing, or flapping.
+type AlertTransition struct {
+	TestName string    `json:"name"`
+	At       time.Time `json:"at"`
+	// RunID is the run that made the alert change.
+	RunID    string `json:"run_id"`
+	Firing   bool   `json:"firing"`
+	Flapping bool   `json:"flapping"`
+}
+
+// A DeliveryAttempt records an attempt at notifying about an alert, or that
+// the notification was held back.
+type DeliveryAttempt struct {
+	DeliveryID string        `json:"delivery_id"`
+	Notifier   string        `json:"notifier"`
+	TestName   string        `json:"name"`
+	RunID      string        `json:"run_id"`
+	Attempt    int           `json:"attempt"`
+	At         time.Time     `json:"at"`
+	Latency    time.Duration `json:"latency"`
+	// StatusCode is that of the response of the notified service, if any.
+	StatusCode int    `json:"status_code,omitempty"`
+	Error      string `json:"error,omitempty"`
+	Final      bool   `json:"final"`
+	// SilencedBy tells what held the notification back, if anything.
+	SilencedBy string `json:"silenced_by,omitempty"`
+}
+
+// A TestMatcher selects the tests whose name matches the glob in Tests, if
+// any, and which have all of Labels.
+type TestMatcher struct {
+	Tests  string            `json:"tests,omitempty"`
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// Matches tells whether the test with the given name and labels is selected.
+func (m *TestMatcher) Matches(name string, labels map[string]string) bool {
+	if m.Tests != "" {
+		if ok, _ := path.Match(m.Tests, name); !ok {
+			return false
+		}
+	}
+	for k, v := range m.Labels {
+		if labels[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// A Silence holds back the notifications about the tests it matches, from
+// StartAt to EndAt. Their alerts keep being tracked.
+type Silence struct {
+	ID string `json:"id"`
+	TestMatcher
+	StartAt   time.Time `json:"start_at"`
+	EndAt     time.Time `json:"end_at"`
+	Comment   string    `json:"comment"`
+	CreatedBy string    `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Active tells whether the silence is in effect at the given time.
+func (s *Silence) Active(now time.Time) bool {
+	return !now.Before(s.StartAt) && now.Before(s.EndAt)
 }
 
 type byStartBefore []TestInstance
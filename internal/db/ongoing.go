@@ -0,0 +1,48 @@
+package db
+
+import (
+	"sort"
+	"sync"
+)
+
+// ongoingTests keeps track of tests in memory, from the moment they are
+// started until they are ended.
+type ongoingTests struct {
+	mu    sync.RWMutex
+	tests map[string]TestInstance
+}
+
+func newOngoingTests() *ongoingTests {
+	return &ongoingTests{tests: make(map[string]TestInstance)}
+}
+
+func (o *ongoingTests) start(test TestInstance) {
+	o.mu.Lock()
+	o.tests[test.TestID] = test
+	o.mu.Unlock()
+}
+
+// end removes a test from the ongoing ones, returning what was known about it.
+func (o *ongoingTests) end(id string) (TestInstance, bool) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	t, ok := o.tests[id]
+	if ok {
+		delete(o.tests, id)
+	}
+	return t, ok
+}
+
+// list returns the ongoing tests, most recently started first.
+func (o *ongoingTests) list() []TestInstance {
+	var ongoing []TestInstance
+	func() {
+		o.mu.RLock()
+		defer o.mu.RUnlock()
+		for _, t := range o.tests {
+			ongoing = append(ongoing, t)
+		}
+	}()
+	sort.Sort(sort.Reverse(byStartBefore(ongoing)))
+	return ongoing
+}
@@ -0,0 +1,148 @@
+package db
+
+import (
+	"math"
+	"time"
+)
+
+// Granularity is the span of time aggregated by a Rollup.
+type Granularity string
+
+// The granularities at which rollups are kept.
+const (
+	Hourly Granularity = "hour"
+	Daily  Granularity = "day"
+)
+
+// Granularities lists the granularities at which rollups are kept.
+var Granularities = []Granularity{Hourly, Daily}
+
+// Truncate returns the start of the period of this granularity containing t.
+func (g Granularity) Truncate(t time.Time) time.Time {
+	t = t.UTC()
+	if g == Daily {
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	}
+	return t.Truncate(time.Hour)
+}
+
+// A Rollup aggregates the runs of a test that started within the same hour,
+// or the same day.
+type Rollup struct {
+	TestName    string        `json:"name"`
+	Granularity Granularity   `json:"granularity"`
+	StartAt     time.Time     `json:"start_at"`
+	Runs        int           `json:"runs"`
+	Passes      int           `json:"passes"`
+	Failures    int           `json:"failures"`
+	MinDuration time.Duration `json:"min_duration"`
+	MaxDuration time.Duration `json:"max_duration"`
+	SumDuration time.Duration `json:"sum_duration"`
+	// Buckets counts the runs by duration, the upper bound of each bucket
+	// being given by rollupBounds. It's what the percentiles are
+	// estimated from.
+	Buckets []int `json:"buckets"`
+}
+
+// rollupBounds are the upper bounds of the duration buckets of a Rollup. Runs
+// that take longer than the last bound are counted in an extra bucket.
+var rollupBounds = []time.Duration{
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	25 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	1 * time.Second,
+	2500 * time.Millisecond,
+	5 * time.Second,
+	10 * time.Second,
+	25 * time.Second,
+	50 * time.Second,
+	100 * time.Second,
+	250 * time.Second,
+	500 * time.Second,
+	1000 * time.Second,
+}
+
+func newRollup(test *TestInstance, g Granularity) *Rollup {
+	return &Rollup{
+		TestName:    test.TestName,
+		Granularity: g,
+		StartAt:     g.Truncate(test.StartAt),
+		Buckets:     make([]int, len(rollupBounds)+1),
+	}
+}
+
+// add accounts for a run of the test in the rollup.
+func (r *Rollup) add(test *TestInstance) {
+	d := test.EndAt.Sub(test.StartAt)
+	if d < 0 {
+		d = 0
+	}
+
+	if r.Runs == 0 || d < r.MinDuration {
+		r.MinDuration = d
+	}
+	if d > r.MaxDuration {
+		r.MaxDuration = d
+	}
+	r.Runs++
+	if test.Pass {
+		r.Passes++
+	} else {
+		r.Failures++
+	}
+	r.SumDuration += d
+
+	if len(r.Buckets) != len(rollupBounds)+1 {
+		r.Buckets = make([]int, len(rollupBounds)+1)
+	}
+	i := 0
+	for i < len(rollupBounds) && d > rollupBounds[i] {
+		i++
+	}
+	r.Buckets[i]++
+}
+
+// AvgDuration is the mean duration of the runs.
+func (r *Rollup) AvgDuration() time.Duration {
+	if r.Runs == 0 {
+		return 0
+	}
+	return r.SumDuration / time.Duration(r.Runs)
+}
+
+// Percentile estimates the duration under which the fraction p of the runs
+// completed, interpolating within the bucket where that percentile falls.
+func (r *Rollup) Percentile(p float64) time.Duration {
+	if r.Runs == 0 {
+		return 0
+	}
+	rank := p * float64(r.Runs)
+	var seen float64
+	for i, n := range r.Buckets {
+		if n == 0 || seen+float64(n) < rank {
+			seen += float64(n)
+			continue
+		}
+		lower, upper := time.Duration(0), r.MaxDuration
+		if i > 0 {
+			lower = rollupBounds[i-1]
+		}
+		if i < len(rollupBounds) && rollupBounds[i] < upper {
+			upper = rollupBounds[i]
+		}
+		if lower < r.MinDuration {
+			lower = r.MinDuration
+		}
+		frac := (rank - seen) / float64(n)
+		d := lower + time.Duration(math.Round(frac*float64(upper-lower)))
+		if d > r.MaxDuration {
+			d = r.MaxDuration
+		}
+		return d
+	}
+	return r.MaxDuration
+}
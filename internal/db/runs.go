@@ -0,0 +1,61 @@
+package db
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"time"
+)
+
+// ErrInvalidCursor is returned when listing runs after a cursor that wasn't
+// obtained from RunCursor.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// A RunFilter selects the runs of tests. Its zero value selects them all.
+type RunFilter struct {
+	// Name is that of the test, or empty for any test.
+	Name string
+	// Pass selects the runs that passed, or that failed, when not nil.
+	Pass *bool
+	// FailKind selects the runs that failed that way, when not empty.
+	FailKind FailureKind
+	// From and To bound the start of the runs, From included and To
+	// excluded. The zero time leaves that end unbounded.
+	From, To time.Time
+}
+
+// A RunPage holds some of the runs selected by a RunFilter, most recently
+// started first.
+type RunPage struct {
+	Runs []TestInstance
+	// TotalCount is the number of runs selected by the filter, across all
+	// pages.
+	TotalCount  int
+	HasNextPage bool
+}
+
+// RunCursor locates a run in the listing of runs, so that a page can start
+// right after it.
+func RunCursor(test *TestInstance) string {
+	return base64.RawURLEncoding.EncodeToString(runKey(test.StartAt, test.TestID))
+}
+
+func parseRunCursor(cursor string) (time.Time, string, error) {
+	key, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil || len(key) < 8 {
+		return time.Time{}, "", ErrInvalidCursor
+	}
+	return runKeyTime(key), string(key[8:]), nil
+}
+
+// Runs are keyed by the time at which they started, then by ID, so that they
+// are sorted chronologically.
+func runKey(startAt time.Time, id string) []byte {
+	key := make([]byte, 8, 8+len(id))
+	binary.BigEndian.PutUint64(key, uint64(startAt.UnixNano()))
+	return append(key, id...)
+}
+
+func runKeyTime(key []byte) time.Time {
+	return time.Unix(0, int64(binary.BigEndian.Uint64(key[:8]))).UTC()
+}
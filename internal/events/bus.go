@@ -0,0 +1,74 @@
+// Package events carries what happens to the runs of tests, as it happens,
+// to whoever is listening.
+package events
+
+import (
+	"context"
+	"sync"
+
+	"github.com/iheanyi/simple-canary/internal/db"
+)
+
+// Kind tells what happened to a run.
+type Kind string
+
+// The kinds of events published about runs.
+const (
+	RunStarted  Kind = "run_started"
+	RunFinished Kind = "run_finished"
+	RunLog      Kind = "run_log"
+)
+
+// An Event is something that happened to a run of a test.
+type Event struct {
+	Kind Kind
+	Run  db.TestInstance
+	// Log is the entry logged by the run, for RunLog events.
+	Log *db.LogEntry
+}
+
+// subscriberBuffer is how many events can wait for a subscriber to receive
+// them before it starts missing some.
+const subscriberBuffer = 64
+
+// A Bus hands the events published on it to its subscribers.
+type Bus struct {
+	mu   sync.RWMutex
+	subs map[chan Event]struct{}
+}
+
+// NewBus creates a bus that has no subscribers yet.
+func NewBus() *Bus {
+	return &Bus{subs: make(map[chan Event]struct{})}
+}
+
+// Publish hands the event to the subscribers. Subscribers that are too slow
+// to keep up miss the event rather than hold up the publisher.
+func (b *Bus) Publish(e Event) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for sub := range b.subs {
+		select {
+		case sub <- e:
+		default:
+		}
+	}
+}
+
+// Subscribe returns the events published from now on, until the context is
+// done, at which point the channel is closed.
+func (b *Bus) Subscribe(ctx context.Context) <-chan Event {
+	sub := make(chan Event, subscriberBuffer)
+	b.mu.Lock()
+	b.subs[sub] = struct{}{}
+	b.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		b.mu.Lock()
+		delete(b.subs, sub)
+		b.mu.Unlock()
+		close(sub)
+	}()
+	return sub
+}
@@ -0,0 +1,49 @@
+package events
+
+import (
+	"fmt"
+
+	"github.com/iheanyi/simple-canary/internal/db"
+	"github.com/sirupsen/logrus"
+)
+
+// LogHook publishes what's logged about a run, recognized by its "test.id"
+// field, as RunLog events on the bus.
+func LogHook(bus *Bus) logrus.Hook {
+	return &logHook{bus: bus}
+}
+
+type logHook struct {
+	bus *Bus
+}
+
+func (h *logHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (h *logHook) Fire(entry *logrus.Entry) error {
+	id, ok := entry.Data["test.id"].(string)
+	if !ok {
+		return nil
+	}
+	name, _ := entry.Data["test.name"].(string)
+
+	fields := make(map[string]string, len(entry.Data))
+	for k, v := range entry.Data {
+		if k == "test.id" || k == "test.name" {
+			continue
+		}
+		fields[k] = fmt.Sprint(v)
+	}
+	h.bus.Publish(Event{
+		Kind: RunLog,
+		Run:  db.TestInstance{TestID: id, TestName: name},
+		Log: &db.LogEntry{
+			Time:    entry.Time,
+			Level:   entry.Level.String(),
+			Message: entry.Message,
+			Fields:  fields,
+		},
+	})
+	return nil
+}
@@ -2,11 +2,17 @@ package canary
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"regexp"
 	"time"
 
+	"github.com/iheanyi/simple-canary/internal/alert"
+	"github.com/iheanyi/simple-canary/internal/auth"
 	"github.com/iheanyi/simple-canary/internal/js"
 	jsctx "github.com/iheanyi/simple-canary/internal/js/context"
 	"github.com/iheanyi/simple-canary/internal/js/ottoutil"
@@ -23,6 +29,7 @@ func Load(vm *otto.Otto, src io.Reader) (*Config, []*js.TestConfig, error) {
 	configVM.Set("settings", ctx.ottoFuncSettings)
 	configVM.Set("file", ctx.ottoFuncFile)
 	configVM.Set("register_test", ctx.ottoFuncRegisterTest)
+	configVM.Set("notifier", ctx.ottoFuncNotifier)
 
 	if err := jsctx.LoadStdLib(context.Background(), configVM, "std"); err != nil {
 		return nil, nil, fmt.Errorf("can't load stdlib: %v", err)
@@ -40,23 +47,52 @@ func Load(vm *otto.Otto, src io.Reader) (*Config, []*js.TestConfig, error) {
 	if ctx.cfg == nil {
 		ctx.cfg = new(Config)
 	}
+	ctx.cfg.Notifiers = ctx.notifiers
+	if ctx.cfg.Route != nil {
+		if err := ctx.cfg.Route.Check(ctx.notifiers); err != nil {
+			return nil, nil, fmt.Errorf("can't apply configuration: %v", err)
+		}
+	}
+	h := sha256.New()
+	h.Write(source)
+	for _, test := range ctx.tests {
+		h.Write([]byte(test.Source))
+	}
+	ctx.cfg.Hash = hex.EncodeToString(h.Sum(nil))[:12]
 	return ctx.cfg, ctx.tests, nil
 }
 
 // Config holds the global canary configuration.
 type Config struct {
 	Name string
+	// Auth controls access to the canary, if set.
+	Auth *auth.Config
+	// Hash identifies the contents of the config, including the scripts of
+	// its tests.
+	Hash string
+	// Notifiers deliver the alerts about the tests.
+	Notifiers []alert.Notifier
+	// Route picks the notifiers of the alerts about each test. Without
+	// one, the alerts go to all the notifiers.
+	Route *alert.Route
+	// Maintenance are the recurring windows during which the notifications
+	// about some tests are held back.
+	Maintenance []*alert.Maintenance
 }
 
 type ctx struct {
-	cfg   *Config
-	tests []*js.TestConfig
+	cfg       *Config
+	tests     []*js.TestConfig
+	notifiers []alert.Notifier
 }
 
 type testConfig struct {
 	Name      string
 	Frequency time.Duration
 	Timeout   time.Duration
+	SLO       *js.SLO
+	Alert     js.AlertRule
+	Labels    map[string]string
 }
 
 func (ctx *ctx) ottoFuncFile(call otto.FunctionCall) otto.Value {
@@ -73,13 +109,17 @@ func (ctx *ctx) ottoFuncFile(call otto.FunctionCall) otto.Value {
 }
 
 func (ctx *ctx) ottoFuncRegisterTest(call otto.FunctionCall) otto.Value {
-	cfg := new(testConfig)
+	cfg := &testConfig{Alert: js.DefaultAlertRule}
 	cfg.load(call.Otto, call.Argument(0))
 	src := ottoutil.String(call.Otto, call.Argument(1))
 	test := &js.TestConfig{
 		Name:      cfg.Name,
+		Source:    src,
 		Frequency: cfg.Frequency,
 		Timeout:   cfg.Timeout,
+		SLO:       cfg.SLO,
+		Alert:     cfg.Alert,
+		Labels:    cfg.Labels,
 	}
 	var err error
 	test.Script, err = call.Otto.Compile("", src)
@@ -99,9 +139,58 @@ func (cfg *Config) load(vm *otto.Otto, config otto.Value) {
 			}
 			return
 		},
+		"auth": func(v otto.Value) error {
+			if !v.IsDefined() {
+				return nil
+			}
+			obj, err := v.Export()
+			if err != nil {
+				return err
+			}
+			data, err := json.Marshal(obj)
+			if err != nil {
+				return err
+			}
+			cfg.Auth, err = auth.Parse(data)
+			return err
+		},
+		"route": func(v otto.Value) error {
+			if !v.IsDefined() {
+				return nil
+			}
+			obj, err := v.Export()
+			if err != nil {
+				return err
+			}
+			data, err := json.Marshal(obj)
+			if err != nil {
+				return err
+			}
+			cfg.Route, err = alert.ParseRoute(data)
+			return err
+		},
+		"maintenance": func(v otto.Value) error {
+			if !v.IsDefined() {
+				return nil
+			}
+			obj, err := v.Export()
+			if err != nil {
+				return err
+			}
+			data, err := json.Marshal(obj)
+			if err != nil {
+				return err
+			}
+			cfg.Maintenance, err = alert.ParseMaintenance(data)
+			return err
+		},
 	})
 }
 
+// labelName matches the label names that Prometheus accepts, as the labels
+// of the tests end up in the rules written by canaryctl.
+var labelName = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
 func (cfg *testConfig) load(vm *otto.Otto, config otto.Value) {
 	ottoutil.LoadObject(vm, config, map[string]func(otto.Value) error{
 		"name": func(v otto.Value) (err error) {
@@ -116,7 +205,122 @@ func (cfg *testConfig) load(vm *otto.Otto, config otto.Value) {
 			cfg.Timeout = ottoutil.Duration(vm, v)
 			return nil
 		},
+		"slo": func(v otto.Value) error {
+			if !v.IsDefined() {
+				return nil
+			}
+			cfg.SLO = loadSLO(vm, v)
+			return nil
+		},
+		"alert": func(v otto.Value) error {
+			if !v.IsDefined() {
+				return nil
+			}
+			cfg.Alert = loadAlertRule(vm, v)
+			return nil
+		},
+		"labels": func(v otto.Value) error {
+			if v.IsDefined() {
+				cfg.Labels = ottoutil.StringMap(vm, v)
+			}
+			for name := range cfg.Labels {
+				if !labelName.MatchString(name) {
+					return fmt.Errorf("%q isn't a valid label name, which must match %s", name, labelName)
+				}
+			}
+			return nil
+		},
+	})
+}
+
+// loadAlertRule reads an alert rule. Unlike the resolution, which defaults to
+// the first pass, when the alert fires must be spelled out.
+func loadAlertRule(vm *otto.Otto, config otto.Value) js.AlertRule {
+	rule := js.AlertRule{ConsecutivePasses: js.DefaultAlertRule.ConsecutivePasses}
+	count := func(dst *int) func(otto.Value) error {
+		return func(v otto.Value) error {
+			if !v.IsDefined() {
+				return nil
+			}
+			*dst = ottoutil.Int(vm, v)
+			if *dst < 0 {
+				return fmt.Errorf("can't be negative, was %d", *dst)
+			}
+			return nil
+		}
+	}
+	duration := func(dst *time.Duration) func(otto.Value) error {
+		return func(v otto.Value) error {
+			if v.IsDefined() {
+				*dst = ottoutil.Duration(vm, v)
+			}
+			return nil
+		}
+	}
+	ottoutil.LoadObject(vm, config, map[string]func(otto.Value) error{
+		"consecutive_failures": count(&rule.ConsecutiveFailures),
+		"failures":             count(&rule.Failures),
+		"window":               duration(&rule.Window),
+		"consecutive_passes":   count(&rule.ConsecutivePasses),
+		"flap_changes":         count(&rule.FlapChanges),
+		"flap_window":          duration(&rule.FlapWindow),
+	})
+	switch {
+	case rule.ConsecutiveFailures == 0 && rule.Failures == 0:
+		ottoutil.Throw(vm, "alert needs consecutive_failures or failures")
+	case rule.Failures > 0 && rule.Window <= 0:
+		ottoutil.Throw(vm, "alert needs a window to count failures in")
+	case rule.ConsecutivePasses == 0:
+		ottoutil.Throw(vm, "alert needs consecutive_passes to resolve")
+	case rule.FlapChanges > 0 && rule.FlapWindow <= 0:
+		ottoutil.Throw(vm, "alert needs a flap_window to count changes in")
+	}
+	return rule
+}
+
+func loadSLO(vm *otto.Otto, config otto.Value) *js.SLO {
+	slo := &js.SLO{Window: 30 * 24 * time.Hour}
+	ottoutil.LoadObject(vm, config, map[string]func(otto.Value) error{
+		"target": func(v otto.Value) error {
+			if !v.IsDefined() {
+				return fmt.Errorf("is required")
+			}
+			slo.Target = ottoutil.Float64(vm, v)
+			if slo.Target <= 0 || slo.Target >= 100 {
+				return fmt.Errorf("must be a percentage between 0 and 100, was %v", slo.Target)
+			}
+			return nil
+		},
+		"window": func(v otto.Value) error {
+			if v.IsDefined() {
+				slo.Window = ottoutil.Duration(vm, v)
+			}
+			return nil
+		},
 	})
+	return slo
+}
+
+func (ctx *ctx) ottoFuncNotifier(call otto.FunctionCall) otto.Value {
+	obj, err := call.Argument(0).Export()
+	if err != nil {
+		ottoutil.Throw(call.Otto, "%s", err)
+	}
+	data, err := json.Marshal(obj)
+	if err != nil {
+		ottoutil.Throw(call.Otto, "%s", err)
+	}
+	n, err := alert.ParseNotifier(data)
+	if err != nil {
+		ottoutil.Throw(call.Otto, "%s", err)
+	}
+	for _, other := range ctx.notifiers {
+		if other.Name() == n.Name() {
+			ottoutil.Throw(call.Otto, "notifier %q is already defined", n.Name())
+		}
+	}
+	ctx.notifiers = append(ctx.notifiers, n)
+	return otto.UndefinedValue()
 }
 
 func (ctx *ctx) ottoFuncSettings(call otto.FunctionCall) otto.Value {
@@ -0,0 +1,63 @@
+package context
+
+import (
+	"reflect"
+	"sync"
+
+	"github.com/robertkrimen/otto"
+)
+
+// Causes remembers the Go errors behind the exceptions that the packages throw
+// into a VM, so that the error a script ends with can be traced back to them.
+// A nil Causes remembers nothing.
+type Causes struct {
+	mu     sync.Mutex
+	thrown []thrownCause
+}
+
+// A thrownCause is an error along with the one its exception makes when the
+// script doesn't catch it.
+type thrownCause struct {
+	err       error
+	exception *otto.Error
+}
+
+// An AssertionError is the cause of the exceptions thrown by log.fail.
+type AssertionError struct {
+	Message string
+}
+
+func (err *AssertionError) Error() string { return err.Message }
+
+// throw throws the error into the VM, remembering the exception it's thrown as.
+func (c *Causes) throw(vm *otto.Otto, err error) {
+	exception, _ := vm.Call("new Error", nil, err.Error())
+	if c != nil {
+		_, uncaught := vm.Call("(function(e) { throw e; })", nil, exception)
+		if oe, ok := uncaught.(*otto.Error); ok {
+			c.mu.Lock()
+			c.thrown = append(c.thrown, thrownCause{err: err, exception: oe})
+			c.mu.Unlock()
+		}
+	}
+	panic(exception)
+}
+
+// Of returns the cause of the error that a script ended with, or nil if the
+// script threw the error on its own. The errors that otto makes of an
+// exception share its stack trace, so only those of the same exception are
+// equal, whatever their messages.
+func (c *Causes) Of(err error) error {
+	oe, ok := err.(*otto.Error)
+	if c == nil || !ok {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i := len(c.thrown) - 1; i >= 0; i-- {
+		if reflect.DeepEqual(c.thrown[i].exception, oe) {
+			return c.thrown[i].err
+		}
+	}
+	return nil
+}
@@ -1,9 +1,11 @@
 package context
 
 import (
+	"crypto/tls"
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"net/http/httptrace"
 	"strings"
 
 	"github.com/iheanyi/simple-canary/internal/js/ottoutil"
@@ -13,18 +15,29 @@ import (
 var q = otto.UndefinedValue()
 
 // LoadHTTP loads an HTTP package in the VM that sends HTTP requests using the
-// given client.
-func LoadHTTP(vm *otto.Otto, pkgname string, client *http.Client, cfgReq func(*http.Request) *http.Request) error {
-	v, err := (&httpPkg{client: client, cfgReq: cfgReq}).load(vm)
+// given client. The errors of the requests are remembered in causes.
+func LoadHTTP(vm *otto.Otto, pkgname string, client *http.Client, cfgReq func(*http.Request) *http.Request, causes *Causes) error {
+	v, err := (&httpPkg{client: client, cfgReq: cfgReq, causes: causes}).load(vm)
 	if err != nil {
 		return err
 	}
 	return vm.Set(pkgname, v)
 }
 
+// A HandshakeError is the cause of the exceptions thrown by requests whose TLS
+// handshake failed.
+type HandshakeError struct {
+	Err error
+}
+
+func (err *HandshakeError) Error() string { return err.Err.Error() }
+
+func (err *HandshakeError) Unwrap() error { return err.Err }
+
 type httpPkg struct {
 	client *http.Client
 	cfgReq func(*http.Request) *http.Request
+	causes *Causes
 }
 
 func (hpkg *httpPkg) load(vm *otto.Otto) (otto.Value, error) {
@@ -62,14 +75,33 @@ func (hpkg *httpPkg) do(all otto.FunctionCall) otto.Value {
 			req.Header.Add(k, val)
 		}
 	}
-	resp, err := hpkg.client.Do(hpkg.cfgReq(req))
+	// The handshake may be done by another goroutine, after the request gave
+	// up on it.
+	handshake := make(chan error, 1)
+	req = hpkg.cfgReq(req)
+	req = req.WithContext(httptrace.WithClientTrace(req.Context(), &httptrace.ClientTrace{
+		TLSHandshakeDone: func(_ tls.ConnectionState, err error) {
+			if err != nil {
+				select {
+				case handshake <- err:
+				default:
+				}
+			}
+		},
+	}))
+	resp, err := hpkg.client.Do(req)
 	if err != nil {
-		ottoutil.Throw(vm, err.Error())
+		select {
+		case <-handshake:
+			err = &HandshakeError{Err: err}
+		default:
+		}
+		hpkg.causes.throw(vm, err)
 	}
 	defer resp.Body.Close()
 	respBody, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		ottoutil.Throw(vm, err.Error())
+		hpkg.causes.throw(vm, err)
 	}
 
 	v, err := vm.Run(`({})`)
@@ -10,14 +10,15 @@ import (
 	log "github.com/sirupsen/logrus"
 )
 
-// LoadLog loads a log package in the VM that logs to the given logger
-func LoadLog(vm *otto.Otto, pkgname string, ll log.FieldLogger) error {
+// LoadLog loads a log package in the VM that logs to the given logger. The
+// failures of log.fail are remembered in causes.
+func LoadLog(vm *otto.Otto, pkgname string, ll log.FieldLogger, causes *Causes) error {
 	// Setup the logging formatter to be structured as JSON formatted.
 	log.SetFormatter(&log.JSONFormatter{})
 	// Output the stdout for capturing.
 	log.SetOutput(os.Stdout)
 
-	v, err := (&logger{ll: ll}).load(vm)
+	v, err := (&logger{ll: ll, causes: causes}).load(vm)
 	if err != nil {
 		return err
 	}
@@ -25,7 +26,8 @@ func LoadLog(vm *otto.Otto, pkgname string, ll log.FieldLogger) error {
 }
 
 type logger struct {
-	ll log.FieldLogger
+	ll     log.FieldLogger
+	causes *Causes
 }
 
 func (ll *logger) load(vm *otto.Otto) (otto.Value, error) {
@@ -83,7 +85,7 @@ func (ll *logger) kv(all otto.FunctionCall) otto.Value {
 	default:
 		ottoutil.Throw(vm, "invalid call to log.kv")
 	}
-	v, err := (&logger{ll: child}).load(vm)
+	v, err := (&logger{ll: child, causes: ll.causes}).load(vm)
 	if err != nil {
 		ottoutil.Throw(vm, err.Error())
 	}
@@ -108,6 +110,6 @@ func (ll *logger) fail(all otto.FunctionCall) otto.Value {
 	vm := all.Otto
 	msg := ottoutil.String(vm, all.Argument(0))
 	ll.ll.Error(msg)
-	ottoutil.Throw(all.Otto, msg)
+	ll.causes.throw(vm, &AssertionError{Message: msg})
 	return q
 }
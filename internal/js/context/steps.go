@@ -0,0 +1,103 @@
+package context
+
+import (
+	"sync"
+	"time"
+
+	"github.com/iheanyi/simple-canary/internal/db"
+	"github.com/iheanyi/simple-canary/internal/js/ottoutil"
+	"github.com/robertkrimen/otto"
+)
+
+// Steps records the steps that a test goes through. A nil Steps records
+// nothing.
+type Steps struct {
+	mu    sync.Mutex
+	steps []db.Step
+}
+
+// stepSrc wraps the hooks recording the steps in a JS function, so that the
+// exceptions thrown by the steps are rethrown as they are.
+const stepSrc = `(function(begin, end) {
+	return function(name, fn) {
+		var i = begin(name);
+		try {
+			var v = fn();
+		} catch (e) {
+			end(i, String(e));
+			throw e;
+		}
+		end(i);
+		return v;
+	};
+})`
+
+// LoadStep loads a function in the VM that runs a named step of the test,
+// like step('log in', function() { ... }), recording how it went in steps.
+func LoadStep(vm *otto.Otto, fnname string, steps *Steps) error {
+	wrap, err := vm.Run(stepSrc)
+	if err != nil {
+		return err
+	}
+	fn, err := wrap.Call(otto.NullValue(), steps.begin, steps.end)
+	if err != nil {
+		return err
+	}
+	return vm.Set(fnname, fn)
+}
+
+func (s *Steps) begin(all otto.FunctionCall) otto.Value {
+	if s == nil {
+		return q
+	}
+	vm := all.Otto
+	name := ottoutil.String(vm, all.Argument(0))
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.steps = append(s.steps, db.Step{Name: name, StartAt: time.Now().UTC()})
+	v, err := vm.ToValue(len(s.steps) - 1)
+	if err != nil {
+		ottoutil.Throw(vm, "%s", err)
+	}
+	return v
+}
+
+func (s *Steps) end(all otto.FunctionCall) otto.Value {
+	if s == nil {
+		return q
+	}
+	vm := all.Otto
+	i := ottoutil.Int(vm, all.Argument(0))
+	cause := all.Argument(1)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if i < 0 || i >= len(s.steps) {
+		ottoutil.Throw(vm, "unknown step %d", i)
+	}
+	step := &s.steps[i]
+	step.EndAt = time.Now().UTC()
+	step.Pass = !cause.IsDefined()
+	if !step.Pass {
+		step.FailCause = ottoutil.String(vm, cause)
+	}
+	return q
+}
+
+// List returns the steps recorded so far. The steps that are still running,
+// such as when the test timed out, are ended as having failed.
+func (s *Steps) List() []db.Step {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	steps := make([]db.Step, len(s.steps))
+	copy(steps, s.steps)
+	for i := range steps {
+		if steps[i].EndAt.IsZero() {
+			steps[i].EndAt = time.Now().UTC()
+			steps[i].FailCause = "the step didn't finish"
+		}
+	}
+	return steps
+}
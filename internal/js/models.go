@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"time"
 
+	jscontext "github.com/iheanyi/simple-canary/internal/js/context"
 	"github.com/robertkrimen/otto"
 	"github.com/sirupsen/logrus"
 )
@@ -12,8 +13,44 @@ import (
 type TestConfig struct {
 	Name      string
 	Script    *otto.Script
+	Source    string
 	Frequency time.Duration
 	Timeout   time.Duration
+	SLO       *SLO
+	Alert     AlertRule
+	// Labels describe the test, such as the team that owns it, to select
+	// it in alert routes and silences.
+	Labels map[string]string
+}
+
+// An AlertRule tells when the failures of a test make it worth alerting about.
+// Rules left at zero are disabled.
+type AlertRule struct {
+	// The alert fires after ConsecutiveFailures failed runs in a row, or
+	// after Failures failed runs within Window.
+	ConsecutiveFailures int
+	Failures            int
+	Window              time.Duration
+	// The alert resolves after ConsecutivePasses runs in a row pass.
+	ConsecutivePasses int
+	// The alert is flapping, and notifications are held back, while it
+	// started or stopped firing FlapChanges times within FlapWindow.
+	FlapChanges int
+	FlapWindow  time.Duration
+}
+
+// DefaultAlertRule fires on the first failure, and resolves on the first pass.
+var DefaultAlertRule = AlertRule{
+	ConsecutiveFailures: 1,
+	ConsecutivePasses:   1,
+}
+
+// An SLO is the objective of having a share of the runs of a test pass,
+// over a window of time.
+type SLO struct {
+	// Target is the percentage of runs that should pass, e.g. 99.9.
+	Target float64
+	Window time.Duration
 }
 
 // A Test holds the parameters and the script that make a test.
@@ -33,4 +70,6 @@ func (cfg *TestConfig) Test() *Test {
 type Context struct {
 	HTTPClient *http.Client
 	Log        logrus.FieldLogger
+	// Steps records the steps of the test, if not nil.
+	Steps *jscontext.Steps
 }
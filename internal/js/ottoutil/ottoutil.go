@@ -2,6 +2,8 @@ package ottoutil
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/robertkrimen/otto"
@@ -186,12 +188,20 @@ func StringMapSlice(vm *otto.Otto, v otto.Value) map[string][]string {
 	return out
 }
 
-// Duration makes v become a duration, or throws in the VM.
+// Duration makes v become a duration, or throws in the VM. On top of what
+// time.ParseDuration understands, a number of days can be given, like "30d".
 func Duration(vm *otto.Otto, v otto.Value) time.Duration {
 	ov, err := v.ToString()
 	if err != nil {
 		Throw(vm, "needs to be a string, was a %q", v.Class())
 	}
+	if days := strings.TrimSuffix(ov, "d"); days != ov {
+		n, err := strconv.ParseFloat(days, 64)
+		if err != nil {
+			Throw(vm, "can't parse duration: %v", err)
+		}
+		return time.Duration(n * float64(24*time.Hour))
+	}
 	d, err := time.ParseDuration(ov)
 	if err != nil {
 		Throw(vm, "can't parse duration: %v", err)
@@ -2,32 +2,54 @@ package runner
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
+	"strings"
 
+	"github.com/iheanyi/simple-canary/internal/db"
 	"github.com/iheanyi/simple-canary/internal/js"
 	jscontext "github.com/iheanyi/simple-canary/internal/js/context"
 	"github.com/robertkrimen/otto"
 )
 
+// A Failure is the error a test failed with, along with its kind.
+type Failure struct {
+	Kind db.FailureKind
+	Err  error
+}
+
+func (f *Failure) Error() string { return f.Err.Error() }
+
+// Run runs the test, failing with a *Failure if it doesn't pass.
 func Run(ctx context.Context, vm *otto.Otto, jsctx *js.Context, test *js.Test, id string) error {
 	testVM := vm.Copy()
+	// The interrupts are buffered so that the timeout doesn't block when the
+	// script already returned.
+	testVM.Interrupt = make(chan func(), 1)
+	causes := new(jscontext.Causes)
 
 	reqConfig := func(req *http.Request) *http.Request {
 		return req.WithContext(ctx)
 	}
 
 	if err := jscontext.LoadStdLib(ctx, testVM, "std"); err != nil {
-		return fmt.Errorf("can't setup std package in VM: %v", err)
+		return &Failure{Kind: db.FailInfrastructure, Err: fmt.Errorf("can't setup std package in VM: %v", err)}
 	}
 
-	if err := jscontext.LoadHTTP(testVM, "http", jsctx.HTTPClient, reqConfig); err != nil {
-		return fmt.Errorf("can't setup HTTP package in VM: %v", err)
+	if err := jscontext.LoadHTTP(testVM, "http", jsctx.HTTPClient, reqConfig, causes); err != nil {
+		return &Failure{Kind: db.FailInfrastructure, Err: fmt.Errorf("can't setup HTTP package in VM: %v", err)}
 	}
 
-	if err := jscontext.LoadLog(testVM, "log", jsctx.Log); err != nil {
-		return fmt.Errorf("can't setup LOG package in VM: %v", err)
+	if err := jscontext.LoadLog(testVM, "log", jsctx.Log, causes); err != nil {
+		return &Failure{Kind: db.FailInfrastructure, Err: fmt.Errorf("can't setup LOG package in VM: %v", err)}
+	}
+
+	if err := jscontext.LoadStep(testVM, "step", jsctx.Steps); err != nil {
+		return &Failure{Kind: db.FailInfrastructure, Err: fmt.Errorf("can't setup step function in VM: %v", err)}
 	}
 	done := make(chan struct{})
 
@@ -39,10 +61,87 @@ func Run(ctx context.Context, vm *otto.Otto, jsctx *js.Context, test *js.Test, i
 		}
 	}()
 
-	_, err := testVM.Run(test.Script)
+	err := runScript(testVM, test)
+	close(done)
+	if err == nil {
+		return nil
+	}
+	if ctx.Err() == context.DeadlineExceeded {
+		return &Failure{Kind: db.FailTimeout, Err: scriptError(err)}
+	}
+	return &Failure{Kind: classify(causes.Of(err)), Err: scriptError(err)}
+}
+
+// runScript runs the script of the test, turning the interruption of the VM
+// when the test is done into an error.
+func runScript(vm *otto.Otto, test *js.Test) (err error) {
+	defer func() {
+		caught := recover()
+		if caught == nil {
+			return
+		}
+		if cerr, ok := caught.(error); ok && (cerr == context.DeadlineExceeded || cerr == context.Canceled) {
+			err = fmt.Errorf("test interrupted: %v", cerr)
+			return
+		}
+		panic(caught)
+	}()
+
+	_, err = vm.Run(test.Script)
+	return err
+}
+
+// scriptError adds the stack trace to the errors of scripts.
+func scriptError(err error) error {
 	if oe, ok := err.(*otto.Error); ok {
-		err = errors.New(oe.String())
+		return errors.New(oe.String())
 	}
-	close(done)
 	return err
 }
+
+// classify returns the kind of failure that the error behind an exception
+// makes, a script error if there's none.
+func classify(cause error) db.FailureKind {
+	var (
+		assertion *jscontext.AssertionError
+		dnsErr    *net.DNSError
+		netErr    net.Error
+	)
+	switch {
+	case cause == nil:
+		return db.FailScriptError
+	case errors.As(cause, &assertion):
+		return db.FailAssertion
+	case errors.As(cause, &dnsErr):
+		return db.FailDNS
+	case isTLSError(cause):
+		return db.FailTLS
+	case errors.Is(cause, context.DeadlineExceeded), errors.As(cause, &netErr) && netErr.Timeout():
+		return db.FailTimeout
+	default:
+		return db.FailNetwork
+	}
+}
+
+func isTLSError(err error) bool {
+	var (
+		unknownAuthority x509.UnknownAuthorityError
+		hostname         x509.HostnameError
+		invalid          x509.CertificateInvalidError
+		record           tls.RecordHeaderError
+	)
+	if errors.As(err, &unknownAuthority) || errors.As(err, &hostname) || errors.As(err, &invalid) || errors.As(err, &record) {
+		return true
+	}
+	// The alerts that crypto/tls sends or receives come as net.OpErrors of
+	// their own. Its other handshake errors aren't exported, so those are
+	// told apart by their message.
+	var (
+		opErr     *net.OpError
+		handshake *jscontext.HandshakeError
+	)
+	if errors.As(err, &opErr) && (opErr.Op == "remote error" || opErr.Op == "local error") {
+		return true
+	}
+	return errors.As(err, &handshake) && strings.Contains(err.Error(), "tls: ")
+}
@@ -60,6 +60,17 @@ func (n *Node) Gauge(name, description string) prometheus.Gauge {
 	return gauge
 }
 
+// GaugeVec returns a new GaugeVec on the metrics node.
+func (n *Node) GaugeVec(name, description string, labels ...string) *prometheus.GaugeVec {
+	gauge := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: name,
+		Help: description,
+	}, labels)
+
+	n.registry.MustRegister(gauge)
+	return gauge
+}
+
 // Summary returns a new SummaryVector on the metrics node.
 func (n *Node) Summary(name, description string, buckets []float64, labels ...string) *prometheus.SummaryVec {
 	calculatedBuckets := make(map[float64]float64, len(buckets))
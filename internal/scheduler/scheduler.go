@@ -0,0 +1,138 @@
+// Package scheduler runs tests repeatedly, keeping track of when each of them
+// is due to run next.
+package scheduler
+
+import (
+	"sync"
+	"time"
+)
+
+// A Scheduler runs tests at their frequency, unless they're paused.
+type Scheduler struct {
+	mu        sync.RWMutex
+	schedules map[string]*schedule
+	// pauses holds when the pause of each paused test ends, the zero time
+	// standing for never.
+	pauses map[string]time.Time
+}
+
+type schedule struct {
+	frequency time.Duration
+	next      time.Time
+	trigger   chan struct{}
+}
+
+// New creates a scheduler that has nothing scheduled.
+func New() *Scheduler {
+	return &Scheduler{
+		schedules: make(map[string]*schedule),
+		pauses:    make(map[string]time.Time),
+	}
+}
+
+// Every calls run right away, then every frequency, forever. Runs that fall
+// while the named test is paused are skipped. Since run is called
+// synchronously, it should not block for long.
+func (s *Scheduler) Every(name string, frequency time.Duration, run func()) {
+	next := time.Now()
+	trigger := make(chan struct{}, 1)
+	s.mu.Lock()
+	s.schedules[name] = &schedule{frequency: frequency, next: next, trigger: trigger}
+	s.mu.Unlock()
+
+	for {
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-trigger:
+			// Runs asked for don't change when the test is next due.
+			timer.Stop()
+			run()
+			continue
+		case <-timer.C:
+		}
+
+		now := time.Now()
+		paused := s.paused(name, now)
+		next = now.Add(frequency)
+		s.setNext(name, next)
+		if !paused {
+			run()
+		}
+	}
+}
+
+// RunNow has the named test run right away, even if it's paused, and reports
+// whether it's scheduled at all.
+func (s *Scheduler) RunNow(name string) bool {
+	s.mu.RLock()
+	sched, ok := s.schedules[name]
+	s.mu.RUnlock()
+	if !ok {
+		return false
+	}
+	select {
+	case sched.trigger <- struct{}{}:
+	default:
+		// A run is already about to happen.
+	}
+	return true
+}
+
+// Pause keeps the named test from running until the given time, or until it's
+// resumed if that time is zero.
+func (s *Scheduler) Pause(name string, until time.Time) {
+	s.mu.Lock()
+	s.pauses[name] = until
+	s.mu.Unlock()
+}
+
+// Resume lets the named test run again when it's next due.
+func (s *Scheduler) Resume(name string) {
+	s.mu.Lock()
+	delete(s.pauses, name)
+	s.mu.Unlock()
+}
+
+// NextRun returns when the named test is next going to run, and false if it
+// isn't scheduled or is paused until it's resumed.
+func (s *Scheduler) NextRun(name string) (time.Time, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	sched, ok := s.schedules[name]
+	if !ok {
+		return time.Time{}, false
+	}
+	next := sched.next
+	if until, paused := s.pauses[name]; paused {
+		if until.IsZero() {
+			return time.Time{}, false
+		}
+		if next.Before(until) {
+			// Skip the runs that fall during the pause.
+			skipped := (until.Sub(next) + sched.frequency - 1) / sched.frequency
+			next = next.Add(skipped * sched.frequency)
+		}
+	}
+	return next, true
+}
+
+// Scheduled tells whether the named test is being run at its frequency.
+func (s *Scheduler) Scheduled(name string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	_, ok := s.schedules[name]
+	return ok
+}
+
+func (s *Scheduler) paused(name string, now time.Time) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	until, ok := s.pauses[name]
+	return ok && (until.IsZero() || now.Before(until))
+}
+
+func (s *Scheduler) setNext(name string, next time.Time) {
+	s.mu.Lock()
+	s.schedules[name].next = next
+	s.mu.Unlock()
+}
@@ -0,0 +1,108 @@
+// Package slo reports on how tests are doing against their service level
+// objectives, from the history kept in the store.
+package slo
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	dbpkg "github.com/iheanyi/simple-canary/internal/db"
+	"github.com/iheanyi/simple-canary/internal/js"
+)
+
+// Windows are those over which burn rates are reported.
+var Windows = []time.Duration{
+	time.Hour,
+	6 * time.Hour,
+	24 * time.Hour,
+	30 * 24 * time.Hour,
+}
+
+// A Report tells how a test is doing against its SLO.
+type Report struct {
+	Name      string
+	Objective js.SLO
+	// SuccessRatio is the share of runs that passed over the window of the
+	// SLO.
+	SuccessRatio float64
+	// ErrorBudgetRemaining is the share of the failures allowed over the
+	// window of the SLO that are left. It's negative once the SLO is missed.
+	ErrorBudgetRemaining float64
+	BurnRates            []BurnRate
+}
+
+// A BurnRate tells how fast the error budget was spent over a window. At a
+// rate of 1, the budget is spent exactly by the end of the window of the SLO.
+type BurnRate struct {
+	Window       time.Duration
+	Runs         int
+	SuccessRatio float64
+	Rate         float64
+}
+
+// Compute reports on the SLO of the named test as of now. It works from the
+// hourly rollups of the test, so windows start at the top of an hour.
+func Compute(db dbpkg.CanaryStore, name string, objective js.SLO, now time.Time) (*Report, error) {
+	longest := objective.Window
+	for _, w := range Windows {
+		if w > longest {
+			longest = w
+		}
+	}
+	rollups, err := db.ListRollups(name, dbpkg.Hourly, now.Add(-longest), now.Add(time.Hour))
+	if err != nil {
+		return nil, err
+	}
+
+	successRatio := func(w time.Duration) (int, float64) {
+		since := dbpkg.Hourly.Truncate(now.Add(-w))
+		var runs, passes int
+		for _, r := range rollups {
+			if !r.StartAt.Before(since) {
+				runs += r.Runs
+				passes += r.Passes
+			}
+		}
+		if runs == 0 {
+			return 0, 1
+		}
+		return runs, float64(passes) / float64(runs)
+	}
+	allowed := 1 - objective.Target/100
+
+	_, ratio := successRatio(objective.Window)
+	report := &Report{
+		Name:                 name,
+		Objective:            objective,
+		SuccessRatio:         ratio,
+		ErrorBudgetRemaining: 1 - (1-ratio)/allowed,
+	}
+	for _, w := range Windows {
+		runs, ratio := successRatio(w)
+		report.BurnRates = append(report.BurnRates, BurnRate{
+			Window:       w,
+			Runs:         runs,
+			SuccessRatio: ratio,
+			Rate:         (1 - ratio) / allowed,
+		})
+	}
+	return report, nil
+}
+
+// FormatWindow formats a window the way they're written in configs, like
+// "24h" or "30d".
+func FormatWindow(w time.Duration) string {
+	const day = 24 * time.Hour
+	if w > day && w%day == 0 {
+		return fmt.Sprintf("%dd", w/day)
+	}
+	s := w.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}